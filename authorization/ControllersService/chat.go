@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/AlecAivazis/survey/v2"
+	"io"
+	pb "miracle/proto"
+	"strconv"
+)
+
+func runChat(client pb.UserServiceClient, userID int32) error {
+	var peer string
+	err := survey.AskOne(&survey.Input{
+		Message: "Enter the user ID to chat with:",
+	}, &peer)
+	if err != nil {
+		return fmt.Errorf("failed to get user ID: %v", err)
+	}
+
+	peerID, err := strconv.ParseInt(peer, 10, 32)
+	if err != nil {
+		return fmt.Errorf("invalid user ID: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := client.SubscribeMessages(ctx, &pb.SubscribeMessagesRequest{UserId: userID})
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to messages: %v", err)
+	}
+
+	history, err := client.ListConversation(ctx, &pb.ListConversationRequest{
+		UserId: userID,
+		PeerId: int32(peerID),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to load conversation: %v", err)
+	}
+
+	fmt.Println("---- conversation ----")
+	for i := len(history.Messages) - 1; i >= 0; i-- {
+		printMessage(history.Messages[i], userID)
+	}
+
+	go func() {
+		for {
+			msg, err := stream.Recv()
+			if err != nil {
+				if !errors.Is(err, io.EOF) && ctx.Err() == nil {
+					fmt.Printf("Message stream closed: %v\n", err)
+				}
+				return
+			}
+			if msg.SenderId == int32(peerID) {
+				printMessage(msg, userID)
+			}
+		}
+	}()
+
+	for {
+		var content string
+		err := survey.AskOne(&survey.Input{
+			Message: "Message (leave empty to go back):",
+		}, &content)
+		if err != nil {
+			return fmt.Errorf("failed to get message: %v", err)
+		}
+
+		if content == "" {
+			return nil
+		}
+
+		_, err = client.SendMessage(ctx, &pb.SendMessageRequest{
+			SenderId:   userID,
+			ReceiverId: int32(peerID),
+			Content:    content,
+		})
+		if err != nil {
+			fmt.Printf("Failed to send message: %v\n", err)
+		}
+	}
+}
+
+func printMessage(msg *pb.ChatMessage, userID int32) {
+	from := fmt.Sprintf("user %d", msg.SenderId)
+	if msg.SenderId == userID {
+		from = "you"
+	}
+
+	fmt.Printf("[%s] %s: %s\n", msg.Timestamp.AsTime().Local().Format("2006-01-02 15:04"), from, msg.Content)
+}
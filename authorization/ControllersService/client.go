@@ -1,19 +1,25 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"github.com/AlecAivazis/survey/v2"
 	"google.golang.org/grpc"
 	"log"
+	"miracle/conf"
 	pb "miracle/proto"
-)
-
-const (
-	serverAddress = "localhost:50051"
+	"os"
 )
 
 func main() {
-	conn, err := grpc.Dial(serverAddress, grpc.WithInsecure())
+	serverAddress := flag.String("server-address", "localhost:50051", "gRPC server address")
+
+	err := conf.Load(flag.CommandLine, os.Args[1:])
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	conn, err := grpc.Dial(*serverAddress, grpc.WithInsecure())
 	if err != nil {
 		log.Fatalf("Failed to connect to server: %v", err)
 	}
@@ -75,7 +81,7 @@ func runUserCommands(client pb.UserServiceClient, carclient pb.CarServiceClient,
 		var command string
 		commandPrompt := &survey.Select{
 			Message: "Choose a command:",
-			Options: []string{"getProfile", "CarService", "exit"},
+			Options: []string{"getProfile", "CarService", "Chat", "exit"},
 			Default: "getProfile",
 		}
 		err := survey.AskOne(commandPrompt, &command)
@@ -93,6 +99,11 @@ func runUserCommands(client pb.UserServiceClient, carclient pb.CarServiceClient,
 			if err != nil {
 				log.Printf("Error occurred during CarService commands: %v", err)
 			}
+		} else if command == "Chat" {
+			err = runChat(client, userID)
+			if err != nil {
+				log.Printf("Error occurred during chat: %v", err)
+			}
 		} else if command == "exit" {
 			return nil
 		} else {
@@ -0,0 +1,91 @@
+// Package events publishes domain events. Events are written to an outbox
+// table in the same transaction as the change they describe and relayed to the
+// broker afterwards, so a broker outage delays events instead of losing them.
+// Delivery is at least once: consumers should deduplicate on the envelope id.
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Version is the envelope version. It changes when the envelope or an event
+// payload changes incompatibly.
+const Version = 1
+
+const (
+	TypeUserRegistered = "user.registered"
+	TypeCarCreated     = "car.created"
+	TypeCarRented      = "car.rented"
+	TypeCarReturned    = "car.returned"
+	TypeCarDeleted     = "car.deleted"
+)
+
+type Envelope struct {
+	ID         string          `json:"id"`
+	Type       string          `json:"type"`
+	Version    int             `json:"version"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+func NewEnvelope(eventType string, data any) (Envelope, error) {
+	js, err := json.Marshal(data)
+	if err != nil {
+		return Envelope{}, err
+	}
+
+	envelope := Envelope{
+		ID:         uuid.NewString(),
+		Type:       eventType,
+		Version:    Version,
+		OccurredAt: time.Now().UTC(),
+		Data:       js,
+	}
+
+	return envelope, nil
+}
+
+type UserRegistered struct {
+	UserID  int32  `json:"user_id"`
+	Name    string `json:"name"`
+	Surname string `json:"surname"`
+	Email   string `json:"email"`
+}
+
+type CarCreated struct {
+	CarID       int32  `json:"car_id"`
+	OwnerID     int32  `json:"owner_id"`
+	Brand       string `json:"brand"`
+	Description string `json:"description"`
+	Color       string `json:"color"`
+	Year        int32  `json:"year"`
+	Price       int32  `json:"price"`
+}
+
+type CarRented struct {
+	CarID      int32     `json:"car_id"`
+	UserID     int32     `json:"user_id"`
+	Price      int32     `json:"price"`
+	TakingDate time.Time `json:"taking_date"`
+	ReturnDate time.Time `json:"return_date"`
+}
+
+type CarReturned struct {
+	CarID      int32     `json:"car_id"`
+	UserID     int32     `json:"user_id"`
+	ReturnedAt time.Time `json:"returned_at"`
+}
+
+type CarDeleted struct {
+	CarID   int32 `json:"car_id"`
+	OwnerID int32 `json:"owner_id"`
+}
+
+// Broker delivers an event. Publish returns once the broker has accepted it.
+type Broker interface {
+	Publish(ctx context.Context, envelope Envelope) error
+}
@@ -0,0 +1,134 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryOutbox is an Outbox over a slice, standing in for the outbox table.
+type memoryOutbox struct {
+	mu        sync.Mutex
+	envelopes []Envelope
+	published map[string]bool
+	attempts  map[string]int
+}
+
+func newMemoryOutbox() *memoryOutbox {
+	return &memoryOutbox{published: make(map[string]bool), attempts: make(map[string]int)}
+}
+
+func (o *memoryOutbox) add(t *testing.T, eventType string, data any) Envelope {
+	envelope, err := NewEnvelope(eventType, data)
+	assert.NoError(t, err)
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.envelopes = append(o.envelopes, envelope)
+
+	return envelope
+}
+
+func (o *memoryOutbox) Pending(ctx context.Context, limit int) ([]Envelope, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	var pending []Envelope
+	for _, envelope := range o.envelopes {
+		if !o.published[envelope.ID] && len(pending) < limit {
+			pending = append(pending, envelope)
+		}
+	}
+	return pending, nil
+}
+
+func (o *memoryOutbox) MarkPublished(ctx context.Context, id string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.published[id] = true
+	return nil
+}
+
+func (o *memoryOutbox) MarkFailed(ctx context.Context, id string, cause error) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.attempts[id]++
+	return nil
+}
+
+func TestNewEnvelope(t *testing.T) {
+	envelope, err := NewEnvelope(TypeCarDeleted, CarDeleted{CarID: 3, OwnerID: 7})
+	assert.NoError(t, err)
+
+	js, err := json.Marshal(envelope)
+	assert.NoError(t, err)
+
+	var decoded map[string]any
+	assert.NoError(t, json.Unmarshal(js, &decoded))
+
+	assert.Equal(t, "car.deleted", decoded["type"])
+	assert.Equal(t, float64(Version), decoded["version"])
+	assert.NotEmpty(t, decoded["id"])
+	assert.Equal(t, map[string]any{"car_id": float64(3), "owner_id": float64(7)}, decoded["data"])
+}
+
+func TestRelay_Flush(t *testing.T) {
+	outbox := newMemoryOutbox()
+	broker := NewFakeBroker()
+	relay := NewRelay(outbox, broker, time.Hour)
+	relay.batchSize = 2
+
+	first := outbox.add(t, TypeUserRegistered, UserRegistered{UserID: 1})
+	second := outbox.add(t, TypeCarCreated, CarCreated{CarID: 1, OwnerID: 1})
+	third := outbox.add(t, TypeCarRented, CarRented{CarID: 1, UserID: 2})
+
+	broker.SetErr(errors.New("connection refused"))
+
+	published, err := relay.Flush(context.Background())
+	assert.Error(t, err)
+	assert.Zero(t, published)
+	assert.Equal(t, 1, outbox.attempts[first.ID])
+	assert.Empty(t, broker.Published())
+
+	broker.SetErr(nil)
+
+	published, err = relay.Flush(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 3, published)
+
+	var ids []string
+	for _, envelope := range broker.Published() {
+		ids = append(ids, envelope.ID)
+	}
+	assert.Equal(t, []string{first.ID, second.ID, third.ID}, ids)
+
+	published, err = relay.Flush(context.Background())
+	assert.NoError(t, err)
+	assert.Zero(t, published)
+}
+
+func TestRelay_RunNotify(t *testing.T) {
+	outbox := newMemoryOutbox()
+	broker := NewFakeBroker()
+	relay := NewRelay(outbox, broker, time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		relay.Run(ctx)
+		close(done)
+	}()
+
+	outbox.add(t, TypeCarReturned, CarReturned{CarID: 1, UserID: 2})
+	relay.Notify()
+
+	assert.Eventually(t, func() bool { return len(broker.Published()) == 1 }, time.Second, 5*time.Millisecond)
+
+	cancel()
+	<-done
+}
@@ -0,0 +1,45 @@
+package events
+
+import (
+	"context"
+	"sync"
+)
+
+// FakeBroker is an in-process Broker for tests. SetErr makes Publish
+// fail as if the broker were down.
+type FakeBroker struct {
+	mu        sync.Mutex
+	published []Envelope
+	err       error
+}
+
+func NewFakeBroker() *FakeBroker {
+	return &FakeBroker{}
+}
+
+func (b *FakeBroker) Publish(ctx context.Context, envelope Envelope) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.err != nil {
+		return b.err
+	}
+
+	b.published = append(b.published, envelope)
+	return nil
+}
+
+func (b *FakeBroker) SetErr(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.err = err
+}
+
+// Published returns the events accepted so far, in order.
+func (b *FakeBroker) Published() []Envelope {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return append([]Envelope(nil), b.published...)
+}
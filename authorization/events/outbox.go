@@ -0,0 +1,169 @@
+package events
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log"
+	"time"
+)
+
+// Enqueue records an event in the outbox as part of tx. It is published once
+// tx has committed and the relay picks it up.
+func Enqueue(ctx context.Context, tx *sql.Tx, eventType string, data any) error {
+	envelope, err := NewEnvelope(eventType, data)
+	if err != nil {
+		return err
+	}
+
+	js, err := json.Marshal(envelope)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO outbox (id, type, envelope, created_at)
+		VALUES ($1, $2, $3, $4)`,
+		envelope.ID, envelope.Type, js, envelope.OccurredAt)
+	return err
+}
+
+// Outbox is the relay's view of the outbox table.
+type Outbox interface {
+	// Pending returns up to limit unpublished events, oldest first.
+	Pending(ctx context.Context, limit int) ([]Envelope, error)
+	MarkPublished(ctx context.Context, id string) error
+	MarkFailed(ctx context.Context, id string, cause error) error
+}
+
+type PostgresOutbox struct {
+	DB *sql.DB
+}
+
+func (o PostgresOutbox) Pending(ctx context.Context, limit int) ([]Envelope, error) {
+	rows, err := o.DB.QueryContext(ctx, `
+		SELECT envelope
+		FROM outbox
+		WHERE published_at IS NULL
+		ORDER BY created_at, seq
+		LIMIT $1`,
+		limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var envelopes []Envelope
+	for rows.Next() {
+		var js []byte
+		err := rows.Scan(&js)
+		if err != nil {
+			return nil, err
+		}
+
+		var envelope Envelope
+		err = json.Unmarshal(js, &envelope)
+		if err != nil {
+			return nil, err
+		}
+		envelopes = append(envelopes, envelope)
+	}
+
+	return envelopes, rows.Err()
+}
+
+func (o PostgresOutbox) MarkPublished(ctx context.Context, id string) error {
+	_, err := o.DB.ExecContext(ctx, "UPDATE outbox SET published_at = now() WHERE id = $1", id)
+	return err
+}
+
+func (o PostgresOutbox) MarkFailed(ctx context.Context, id string, cause error) error {
+	_, err := o.DB.ExecContext(ctx, `
+		UPDATE outbox
+		SET attempts = attempts + 1, last_error = $1
+		WHERE id = $2`,
+		cause.Error(), id)
+	return err
+}
+
+// Relay moves events from the outbox to the broker. It polls every interval
+// and can be woken early with Notify after a commit.
+type Relay struct {
+	outbox    Outbox
+	broker    Broker
+	interval  time.Duration
+	batchSize int
+	wake      chan struct{}
+}
+
+func NewRelay(outbox Outbox, broker Broker, interval time.Duration) *Relay {
+	return &Relay{
+		outbox:    outbox,
+		broker:    broker,
+		interval:  interval,
+		batchSize: 100,
+		wake:      make(chan struct{}, 1),
+	}
+}
+
+// Notify asks the relay to flush without waiting for the next tick.
+func (r *Relay) Notify() {
+	select {
+	case r.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run flushes the outbox until ctx is cancelled.
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		_, err := r.Flush(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("Failed to publish events: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-r.wake:
+		}
+	}
+}
+
+// Flush publishes pending events in order and returns how many were
+// published. It stops at the first failure so that events are not reordered.
+func (r *Relay) Flush(ctx context.Context) (int, error) {
+	published := 0
+
+	for {
+		envelopes, err := r.outbox.Pending(ctx, r.batchSize)
+		if err != nil {
+			return published, err
+		}
+
+		for _, envelope := range envelopes {
+			err = r.broker.Publish(ctx, envelope)
+			if err != nil {
+				markErr := r.outbox.MarkFailed(ctx, envelope.ID, err)
+				if markErr != nil {
+					log.Printf("Failed to record event publish failure: %v", markErr)
+				}
+				return published, err
+			}
+
+			err = r.outbox.MarkPublished(ctx, envelope.ID)
+			if err != nil {
+				return published, err
+			}
+			published++
+		}
+
+		if len(envelopes) < r.batchSize {
+			return published, nil
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// RabbitMQBroker publishes events to a durable topic exchange with the event
+// type as routing key, waiting for the broker to confirm each message.
+type RabbitMQBroker struct {
+	conn     *amqp.Connection
+	exchange string
+
+	mu sync.Mutex
+	ch *amqp.Channel
+}
+
+func NewRabbitMQBroker(conn *amqp.Connection, exchange string) (*RabbitMQBroker, error) {
+	b := &RabbitMQBroker{conn: conn, exchange: exchange}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	err := b.open()
+	if err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// open must be called with the broker locked.
+func (b *RabbitMQBroker) open() error {
+	ch, err := b.conn.Channel()
+	if err != nil {
+		return err
+	}
+
+	err = ch.ExchangeDeclare(b.exchange, "topic", true, false, false, false, nil)
+	if err != nil {
+		ch.Close()
+		return err
+	}
+
+	err = ch.Confirm(false)
+	if err != nil {
+		ch.Close()
+		return err
+	}
+
+	b.ch = ch
+	return nil
+}
+
+func (b *RabbitMQBroker) Publish(ctx context.Context, envelope Envelope) error {
+	body, err := json.Marshal(envelope)
+	if err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// A channel is closed by the server after a channel-level error.
+	if b.ch == nil || b.ch.IsClosed() {
+		err = b.open()
+		if err != nil {
+			return err
+		}
+	}
+
+	confirmation, err := b.ch.PublishWithDeferredConfirmWithContext(ctx, b.exchange, envelope.Type, false, false, amqp.Publishing{
+		ContentType:  "application/json",
+		DeliveryMode: amqp.Persistent,
+		MessageId:    envelope.ID,
+		Timestamp:    envelope.OccurredAt,
+		Type:         envelope.Type,
+		Body:         body,
+	})
+	if err != nil {
+		return err
+	}
+
+	acked, err := confirmation.WaitContext(ctx)
+	if err != nil {
+		return err
+	}
+	if !acked {
+		return fmt.Errorf("broker rejected event %s", envelope.ID)
+	}
+
+	return nil
+}
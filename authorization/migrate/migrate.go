@@ -0,0 +1,308 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrNoMigrations = errors.New("no migrations to roll back")
+
+// Migration is a versioned pair of SQL scripts. Files are named
+// <version>_<name>.up.sql and <version>_<name>.down.sql.
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+type Status struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+// Migrator applies the migrations in FS and records them in Table, so that
+// services sharing a database keep separate histories.
+type Migrator struct {
+	DB    *sql.DB
+	FS    fs.FS
+	Table string
+}
+
+func Load(fsys fs.FS) ([]Migration, error) {
+	files, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+
+	for _, file := range files {
+		base := path.Base(file)
+
+		var direction string
+		switch {
+		case strings.HasSuffix(base, ".up.sql"):
+			direction = "up"
+		case strings.HasSuffix(base, ".down.sql"):
+			direction = "down"
+		default:
+			return nil, fmt.Errorf("migration %s: must end in .up.sql or .down.sql", base)
+		}
+
+		prefix, name, found := strings.Cut(strings.TrimSuffix(base, "."+direction+".sql"), "_")
+		if !found {
+			return nil, fmt.Errorf("migration %s: must be named <version>_<name>", base)
+		}
+
+		version, err := strconv.ParseInt(prefix, 10, 64)
+		if err != nil || version < 1 {
+			return nil, fmt.Errorf("migration %s: invalid version %q", base, prefix)
+		}
+
+		content, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: name}
+			byVersion[version] = m
+		}
+		if m.Name != name {
+			return nil, fmt.Errorf("migration %d: conflicting names %q and %q", version, m.Name, name)
+		}
+
+		if direction == "up" {
+			m.Up = string(content)
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration %d: missing up script", m.Version)
+		}
+		migrations = append(migrations, *m)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// Up applies every pending migration in version order and returns the ones
+// it applied.
+func (m Migrator) Up() ([]Migration, error) {
+	migrations, err := Load(m.FS)
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.ensureTable()
+	if err != nil {
+		return nil, err
+	}
+
+	var applied []Migration
+
+	for _, migration := range migrations {
+		ok, err := m.apply(migration)
+		if err != nil {
+			return applied, fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+		if ok {
+			applied = append(applied, migration)
+		}
+	}
+
+	return applied, nil
+}
+
+// Down rolls back the most recently applied migration.
+func (m Migrator) Down() (*Migration, error) {
+	migrations, err := Load(m.FS)
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.ensureTable()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	err = m.lock(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	var version int64
+	err = tx.QueryRowContext(ctx, fmt.Sprintf(`SELECT version FROM %s ORDER BY version DESC LIMIT 1`, m.Table)).Scan(&version)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrNoMigrations
+		default:
+			return nil, err
+		}
+	}
+
+	var migration *Migration
+	for i := range migrations {
+		if migrations[i].Version == version {
+			migration = &migrations[i]
+		}
+	}
+
+	if migration == nil {
+		return nil, fmt.Errorf("migration %d is applied but not known to this binary", version)
+	}
+	if migration.Down == "" {
+		return nil, fmt.Errorf("migration %d_%s: missing down script", migration.Version, migration.Name)
+	}
+
+	_, err = tx.ExecContext(ctx, migration.Down)
+	if err != nil {
+		return nil, fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+	}
+
+	_, err = tx.ExecContext(ctx, fmt.Sprintf(`DELETE FROM %s WHERE version = $1`, m.Table), version)
+	if err != nil {
+		return nil, err
+	}
+
+	return migration, tx.Commit()
+}
+
+// Status lists every known migration with the time it was applied, or nil if
+// it is pending.
+func (m Migrator) Status() ([]Status, error) {
+	migrations, err := Load(m.FS)
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.ensureTable()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := m.DB.QueryContext(ctx, fmt.Sprintf(`SELECT version, applied_at FROM %s`, m.Table))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	appliedAt := make(map[int64]time.Time)
+	for rows.Next() {
+		var (
+			version int64
+			at      time.Time
+		)
+		err := rows.Scan(&version, &at)
+		if err != nil {
+			return nil, err
+		}
+		appliedAt[version] = at
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(migrations))
+	for _, migration := range migrations {
+		status := Status{Migration: migration}
+		if at, ok := appliedAt[migration.Version]; ok {
+			status.AppliedAt = &at
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+func (m Migrator) ensureTable() error {
+	query := fmt.Sprintf(`
+		CREATE TABLE IF NOT EXISTS %s (
+			version bigint PRIMARY KEY,
+			name text NOT NULL,
+			applied_at timestamp(0) with time zone NOT NULL DEFAULT NOW()
+		)`, m.Table)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	_, err := m.DB.ExecContext(ctx, query)
+	return err
+}
+
+// apply runs a single migration in its own transaction unless it has already
+// been applied. An advisory lock keeps concurrently starting instances from
+// applying the same migration twice.
+func (m Migrator) apply(migration Migration) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	err = m.lock(ctx, tx)
+	if err != nil {
+		return false, err
+	}
+
+	var exists bool
+	err = tx.QueryRowContext(ctx, fmt.Sprintf(`SELECT EXISTS (SELECT 1 FROM %s WHERE version = $1)`, m.Table), migration.Version).Scan(&exists)
+	if err != nil {
+		return false, err
+	}
+	if exists {
+		return false, nil
+	}
+
+	_, err = tx.ExecContext(ctx, migration.Up)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = tx.ExecContext(ctx, fmt.Sprintf(`INSERT INTO %s (version, name) VALUES ($1, $2)`, m.Table), migration.Version, migration.Name)
+	if err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
+
+func (m Migrator) lock(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, m.Table)
+	return err
+}
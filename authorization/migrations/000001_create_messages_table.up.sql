@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS messages (
+    id serial PRIMARY KEY,
+    sender_id integer NOT NULL,
+    receiver_id integer NOT NULL,
+    content text NOT NULL,
+    created_at timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    CHECK (sender_id <> receiver_id)
+);
+
+CREATE INDEX IF NOT EXISTS messages_conversation_idx
+    ON messages (LEAST(sender_id, receiver_id), GREATEST(sender_id, receiver_id), id);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS outbox (
+    seq bigserial PRIMARY KEY,
+    id uuid NOT NULL UNIQUE,
+    type text NOT NULL,
+    envelope jsonb NOT NULL,
+    created_at timestamp with time zone NOT NULL,
+    published_at timestamp with time zone,
+    attempts integer NOT NULL DEFAULT 0,
+    last_error text
+);
+
+CREATE INDEX IF NOT EXISTS outbox_pending_idx ON outbox (created_at, seq) WHERE published_at IS NULL;
@@ -1,7 +1,7 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
 // versions:
 // - protoc-gen-go-grpc v1.2.0
-// - protoc             v4.23.1
+// - protoc             v3.5.1-go
 // source: proto/func.proto
 
 package proto
@@ -27,6 +27,8 @@ type UserServiceClient interface {
 	ValidateEmail(ctx context.Context, in *EmailValidationRequest, opts ...grpc.CallOption) (*EmailValidationResponse, error)
 	GetUserProfile(ctx context.Context, in *UserProfileRequest, opts ...grpc.CallOption) (*UserProfileResponse, error)
 	SendMessage(ctx context.Context, in *SendMessageRequest, opts ...grpc.CallOption) (*SendMessageResponse, error)
+	ListConversation(ctx context.Context, in *ListConversationRequest, opts ...grpc.CallOption) (*ListConversationResponse, error)
+	SubscribeMessages(ctx context.Context, in *SubscribeMessagesRequest, opts ...grpc.CallOption) (UserService_SubscribeMessagesClient, error)
 }
 
 type userServiceClient struct {
@@ -82,6 +84,47 @@ func (c *userServiceClient) SendMessage(ctx context.Context, in *SendMessageRequ
 	return out, nil
 }
 
+func (c *userServiceClient) ListConversation(ctx context.Context, in *ListConversationRequest, opts ...grpc.CallOption) (*ListConversationResponse, error) {
+	out := new(ListConversationResponse)
+	err := c.cc.Invoke(ctx, "/proto.UserService/ListConversation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) SubscribeMessages(ctx context.Context, in *SubscribeMessagesRequest, opts ...grpc.CallOption) (UserService_SubscribeMessagesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &UserService_ServiceDesc.Streams[0], "/proto.UserService/SubscribeMessages", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &userServiceSubscribeMessagesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type UserService_SubscribeMessagesClient interface {
+	Recv() (*ChatMessage, error)
+	grpc.ClientStream
+}
+
+type userServiceSubscribeMessagesClient struct {
+	grpc.ClientStream
+}
+
+func (x *userServiceSubscribeMessagesClient) Recv() (*ChatMessage, error) {
+	m := new(ChatMessage)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // UserServiceServer is the server API for UserService service.
 // All implementations must embed UnimplementedUserServiceServer
 // for forward compatibility
@@ -91,6 +134,8 @@ type UserServiceServer interface {
 	ValidateEmail(context.Context, *EmailValidationRequest) (*EmailValidationResponse, error)
 	GetUserProfile(context.Context, *UserProfileRequest) (*UserProfileResponse, error)
 	SendMessage(context.Context, *SendMessageRequest) (*SendMessageResponse, error)
+	ListConversation(context.Context, *ListConversationRequest) (*ListConversationResponse, error)
+	SubscribeMessages(*SubscribeMessagesRequest, UserService_SubscribeMessagesServer) error
 	mustEmbedUnimplementedUserServiceServer()
 }
 
@@ -113,6 +158,12 @@ func (UnimplementedUserServiceServer) GetUserProfile(context.Context, *UserProfi
 func (UnimplementedUserServiceServer) SendMessage(context.Context, *SendMessageRequest) (*SendMessageResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SendMessage not implemented")
 }
+func (UnimplementedUserServiceServer) ListConversation(context.Context, *ListConversationRequest) (*ListConversationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListConversation not implemented")
+}
+func (UnimplementedUserServiceServer) SubscribeMessages(*SubscribeMessagesRequest, UserService_SubscribeMessagesServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeMessages not implemented")
+}
 func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
 
 // UnsafeUserServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -216,6 +267,45 @@ func _UserService_SendMessage_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_ListConversation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListConversationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListConversation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.UserService/ListConversation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListConversation(ctx, req.(*ListConversationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_SubscribeMessages_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeMessagesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UserServiceServer).SubscribeMessages(m, &userServiceSubscribeMessagesServer{stream})
+}
+
+type UserService_SubscribeMessagesServer interface {
+	Send(*ChatMessage) error
+	grpc.ServerStream
+}
+
+type userServiceSubscribeMessagesServer struct {
+	grpc.ServerStream
+}
+
+func (x *userServiceSubscribeMessagesServer) Send(m *ChatMessage) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // UserService_ServiceDesc is the grpc.ServiceDesc for UserService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -243,8 +333,18 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SendMessage",
 			Handler:    _UserService_SendMessage_Handler,
 		},
+		{
+			MethodName: "ListConversation",
+			Handler:    _UserService_ListConversation_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeMessages",
+			Handler:       _UserService_SubscribeMessages_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/func.proto",
 }
 
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// authenticatedUser returns the user of the authentication token sent as
+// "authorization: Bearer <token>" metadata. Tokens are issued by the user
+// service and stored in its tokens table, which this service shares.
+func (s *server) authenticatedUser(ctx context.Context) (int32, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	values := md.Get("authorization")
+	if len(values) != 1 {
+		return 0, status.Error(codes.Unauthenticated, "an authentication token must be provided")
+	}
+
+	token := strings.TrimPrefix(values[0], "Bearer ")
+	if token == values[0] || token == "" {
+		return 0, status.Error(codes.Unauthenticated, "invalid or missing authentication token")
+	}
+
+	hash := sha256.Sum256([]byte(token))
+
+	var userID int32
+	err := s.db.QueryRowContext(ctx, `
+		SELECT user_id
+		FROM tokens
+		WHERE hash = $1 AND scope = 'authentication' AND expiry > $2`,
+		hash[:], time.Now()).Scan(&userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return 0, status.Error(codes.Unauthenticated, "invalid or expired authentication token")
+		default:
+			log.Printf("Failed to look up authentication token: %v", err)
+			return 0, status.Error(codes.Internal, "failed to authenticate")
+		}
+	}
+
+	return userID, nil
+}
+
+// authorizeUser checks that the request is made by userID, the user the RPC
+// acts for.
+func (s *server) authorizeUser(ctx context.Context, userID int32) error {
+	authenticated, err := s.authenticatedUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	if authenticated != userID {
+		return status.Error(codes.PermissionDenied, "you can only act on your own conversations")
+	}
+
+	return nil
+}
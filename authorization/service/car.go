@@ -5,9 +5,19 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"miracle/events"
 	pb "miracle/proto"
+	"time"
 )
 
+// isUsedColumn mirrors car-service: a car is in use while one of its active
+// rentals has started.
+const isUsedColumn = `EXISTS (
+			SELECT 1 FROM rented_cars
+			WHERE rented_cars.car_id = car.id
+			AND rented_cars.status = 'active'
+			AND rented_cars.taking_date <= now())`
+
 func (s *server) CreateCar(ctx context.Context, req *pb.CreateCarRequest) (*pb.CreateCarResponse, error) {
 	ownedCar, err := s.checkUserOwnedCar(ctx, req.OwnerId)
 	if err != nil {
@@ -24,16 +34,23 @@ func (s *server) CreateCar(ctx context.Context, req *pb.CreateCarRequest) (*pb.C
 		return nil, fmt.Errorf("failed to create car")
 	}
 
-	_, err = s.db.ExecContext(ctx, `
-		INSERT INTO car (id, brand, owner_id, is_used, description, color, year, price)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
-		carID, req.Brand, req.OwnerId, false, req.Description, req.Color, req.Year, req.Price)
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("Failed to begin transaction: %v", err)
+		return nil, fmt.Errorf("failed to create car")
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO car (id, brand, owner_id, description, color, year, price)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		carID, req.Brand, req.OwnerId, req.Description, req.Color, req.Year, req.Price)
 	if err != nil {
 		log.Printf("Failed to create car: %v", err)
 		return nil, fmt.Errorf("failed to create car")
 	}
 
-	_, err = s.db.ExecContext(ctx, `
+	_, err = tx.ExecContext(ctx, `
 		UPDATE users
 		SET owned_car = $1
 		WHERE id = $2`,
@@ -43,6 +60,27 @@ func (s *server) CreateCar(ctx context.Context, req *pb.CreateCarRequest) (*pb.C
 		return nil, fmt.Errorf("failed to create car")
 	}
 
+	err = events.Enqueue(ctx, tx, events.TypeCarCreated, events.CarCreated{
+		CarID:       carID,
+		OwnerID:     req.OwnerId,
+		Brand:       req.Brand,
+		Description: req.Description,
+		Color:       req.Color,
+		Year:        req.Year,
+		Price:       req.Price,
+	})
+	if err != nil {
+		log.Printf("Failed to enqueue event: %v", err)
+		return nil, fmt.Errorf("failed to create car")
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		log.Printf("Failed to commit car: %v", err)
+		return nil, fmt.Errorf("failed to create car")
+	}
+	s.notifyEvents()
+
 	response := &pb.CreateCarResponse{
 		CarId: carID,
 	}
@@ -64,132 +102,184 @@ func (s *server) checkUserOwnedCar(ctx context.Context, userID int32) (bool, err
 }
 
 func (s *server) RentCar(ctx context.Context, req *pb.RentCarRequest) (*pb.RentCarResponse, error) {
-	rentedCar, err := s.checkUserRentedCar(ctx, req.UserId)
+	takingDate := time.Now()
+	if req.TakingDate != nil {
+		takingDate = req.TakingDate.AsTime()
+	}
+
+	returnDate := takingDate.Add(24 * time.Hour)
+	if req.ReturnDate != nil {
+		returnDate = req.ReturnDate.AsTime()
+		if !returnDate.After(takingDate) {
+			return nil, fmt.Errorf("return date must be after taking date")
+		}
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
-		log.Printf("Failed to check user's rented car: %v", err)
+		log.Printf("Failed to begin transaction: %v", err)
 		return nil, fmt.Errorf("failed to rent car")
 	}
-	if rentedCar {
-		return nil, fmt.Errorf("user is already renting a car and cannot rent multiple cars")
-	}
+	defer tx.Rollback()
 
-	var ownerID int32
-	err = s.db.QueryRowContext(ctx, "SELECT owner_id FROM car WHERE id = $1", req.CarId).Scan(&ownerID)
+	var price int32
+	err = tx.QueryRowContext(ctx, "SELECT price FROM car WHERE id = $1 FOR UPDATE", req.CarId).Scan(&price)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			log.Printf("Car not found for ID: %d", req.CarId)
 			return nil, fmt.Errorf("car not found")
 		}
-		log.Printf("Failed to retrieve car owner ID: %v", err)
+		log.Printf("Failed to lock car: %v", err)
 		return nil, fmt.Errorf("failed to rent car")
 	}
 
-	rentID, err := generateUniqueIDforRent(s.db)
+	var rentedCar int32
+	err = tx.QueryRowContext(ctx, "SELECT rented_car FROM users WHERE id = $1 FOR UPDATE", req.UserId).Scan(&rentedCar)
 	if err != nil {
-		log.Printf("Failed to generate unique ID: %v", err)
+		if err == sql.ErrNoRows {
+			log.Printf("User not found for ID: %d", req.UserId)
+			return nil, fmt.Errorf("user not found")
+		}
+		log.Printf("Failed to lock user: %v", err)
+		return nil, fmt.Errorf("failed to rent car")
+	}
+	if rentedCar != 0 {
+		return nil, fmt.Errorf("user is already renting a car and cannot rent multiple cars")
+	}
+
+	_, err = tx.ExecContext(ctx, "UPDATE users SET rented_car = rented_car + 1 WHERE id = $1", req.UserId)
+	if err != nil {
+		log.Printf("Failed to update rented_cars count: %v", err)
 		return nil, fmt.Errorf("failed to rent car")
 	}
 
-	_, err = s.db.ExecContext(ctx, `
-		INSERT INTO rented_cars (id, user_id, car_id, price, taking_date, return_date)
-			VALUES ($1, $2, $3, $4, $5, $6)`,
-		rentID, req.UserId, req.CarId, req.Price, req.TakingDate, req.ReturnDate)
+	var occupied bool
+	err = tx.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM rented_cars
+			WHERE car_id = $1
+			AND status = 'active'
+			AND taking_date < $3
+			AND GREATEST(return_date, now()) > $2)`,
+		req.CarId, takingDate, returnDate).Scan(&occupied)
+	if err != nil {
+		log.Printf("Failed to check car availability: %v", err)
+		return nil, fmt.Errorf("failed to rent car")
+	}
+	if occupied {
+		return nil, fmt.Errorf("the car is already occupied")
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO rented_cars (user_id, car_id, price, taking_date, return_date, status)
+		VALUES ($1, $2, $3, $4, $5, 'active')`,
+		req.UserId, req.CarId, price, takingDate, returnDate)
 	if err != nil {
 		log.Printf("Failed to rent car: %v", err)
 		return nil, fmt.Errorf("failed to rent car")
 	}
 
-	_, err = s.db.ExecContext(ctx, `
-		UPDATE users
-		SET rented_car = rented_car + 1
-		WHERE id = $1`,
-		req.UserId)
+	err = events.Enqueue(ctx, tx, events.TypeCarRented, events.CarRented{
+		CarID:      req.CarId,
+		UserID:     req.UserId,
+		Price:      price,
+		TakingDate: takingDate,
+		ReturnDate: returnDate,
+	})
 	if err != nil {
-		log.Printf("Failed to update rented_cars count: %v", err)
+		log.Printf("Failed to enqueue event: %v", err)
 		return nil, fmt.Errorf("failed to rent car")
 	}
 
-	_, err = s.db.ExecContext(ctx, `
-		UPDATE car
-		SET is_used = true
-		WHERE id = $1`,
-		req.CarId)
+	err = tx.Commit()
 	if err != nil {
-		log.Printf("Failed to update is_used flag: %v", err)
+		log.Printf("Failed to commit rent: %v", err)
 		return nil, fmt.Errorf("failed to rent car")
 	}
+	s.notifyEvents()
 
 	response := &pb.RentCarResponse{
-		CarId: req.CarId,
+		CarId:  req.CarId,
+		UserId: req.UserId,
 	}
 
 	return response, nil
 }
 
 func (s *server) ReturnCar(ctx context.Context, req *pb.ReturnCarRequest) (*pb.ReturnCarResponse, error) {
-	rentedCar, err := s.checkUserRentedCar(ctx, req.UserId)
+	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
-		log.Printf("Failed to check user's rented car: %v", err)
+		log.Printf("Failed to begin transaction: %v", err)
 		return nil, fmt.Errorf("failed to return car")
 	}
-	if !rentedCar {
-		return nil, fmt.Errorf("user is not currently renting a car")
+	defer tx.Rollback()
+
+	var carID int32
+	err = tx.QueryRowContext(ctx, "SELECT id FROM car WHERE id = $1 FOR UPDATE", req.CarId).Scan(&carID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Printf("Car not found for ID: %d", req.CarId)
+			return nil, fmt.Errorf("car not found")
+		}
+		log.Printf("Failed to lock car: %v", err)
+		return nil, fmt.Errorf("failed to return car")
 	}
 
-	_, err = s.db.ExecContext(ctx, `
-		DELETE FROM rented_cars
-		WHERE user_id = $1 AND car_id = $2`,
-		req.UserId, req.CarId)
+	var returnedAt time.Time
+	err = tx.QueryRowContext(ctx, `
+		UPDATE rented_cars
+		SET status = 'returned', returned_at = now()
+		WHERE user_id = $1 AND car_id = $2 AND status = 'active' AND taking_date <= now()
+		RETURNING returned_at`,
+		req.UserId, req.CarId).Scan(&returnedAt)
 	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("user is not currently renting this car")
+		}
 		log.Printf("Failed to return car: %v", err)
 		return nil, fmt.Errorf("failed to return car")
 	}
 
-	_, err = s.db.ExecContext(ctx, `
+	_, err = tx.ExecContext(ctx, `
 		UPDATE users
 		SET rented_car = rented_car - 1
-		WHERE id = $1`,
+		WHERE id = $1 AND rented_car > 0`,
 		req.UserId)
 	if err != nil {
 		log.Printf("Failed to update rented_cars count: %v", err)
 		return nil, fmt.Errorf("failed to return car")
 	}
 
-	_, err = s.db.ExecContext(ctx, `
-		UPDATE car
-		SET is_used = false
-		WHERE id = $1`,
-		req.CarId)
+	err = events.Enqueue(ctx, tx, events.TypeCarReturned, events.CarReturned{
+		CarID:      req.CarId,
+		UserID:     req.UserId,
+		ReturnedAt: returnedAt,
+	})
+	if err != nil {
+		log.Printf("Failed to enqueue event: %v", err)
+		return nil, fmt.Errorf("failed to return car")
+	}
+
+	err = tx.Commit()
 	if err != nil {
-		log.Printf("Failed to update is_used flag: %v", err)
+		log.Printf("Failed to commit return: %v", err)
 		return nil, fmt.Errorf("failed to return car")
 	}
+	s.notifyEvents()
 
 	response := &pb.ReturnCarResponse{
-		CarId: req.CarId,
+		CarId:  req.CarId,
+		UserId: req.UserId,
 	}
 
 	return response, nil
 }
 
-func (s *server) checkUserRentedCar(ctx context.Context, userID int32) (bool, error) {
-	row := s.db.QueryRowContext(ctx, "SELECT rented_car FROM users WHERE id = $1", userID)
-	var rentedCar int
-	err := row.Scan(&rentedCar)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return false, nil
-		}
-		return false, err
-	}
-	return rentedCar > 0, nil
-}
-
 func (s *server) GetCarInfo(ctx context.Context, req *pb.GetCarInfoRequest) (*pb.GetCarInfoResponse, error) {
 	query := `
-		SELECT id, brand, description, color, year, price, is_used, owner_id
+		SELECT id, brand, description, color, year, price, ` + isUsedColumn + `, owner_id
 		FROM car
-		WHERE id = $1 
+		WHERE id = $1
 	`
 	row := s.db.QueryRowContext(ctx, query, req.CarId)
 
@@ -232,7 +322,14 @@ func (s *server) DeleteCar(ctx context.Context, req *pb.DeleteCarRequest) (*pb.D
 		return nil, fmt.Errorf("cannot delete a car that is currently rented")
 	}
 
-	_, err = s.db.ExecContext(ctx, `
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("Failed to begin transaction: %v", err)
+		return nil, fmt.Errorf("failed to delete car")
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
 		DELETE FROM car
 		WHERE id = $1`,
 		req.CarId)
@@ -241,6 +338,22 @@ func (s *server) DeleteCar(ctx context.Context, req *pb.DeleteCarRequest) (*pb.D
 		return nil, fmt.Errorf("failed to delete car")
 	}
 
+	err = events.Enqueue(ctx, tx, events.TypeCarDeleted, events.CarDeleted{
+		CarID:   req.CarId,
+		OwnerID: carInfo.OwnerId,
+	})
+	if err != nil {
+		log.Printf("Failed to enqueue event: %v", err)
+		return nil, fmt.Errorf("failed to delete car")
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		log.Printf("Failed to commit delete: %v", err)
+		return nil, fmt.Errorf("failed to delete car")
+	}
+	s.notifyEvents()
+
 	response := &pb.DeleteCarResponse{
 		CarId: req.CarId,
 	}
@@ -250,9 +363,9 @@ func (s *server) DeleteCar(ctx context.Context, req *pb.DeleteCarRequest) (*pb.D
 
 func (s *server) GetAvailableCars(ctx context.Context, req *pb.GetAvailableCarsRequest) (*pb.GetAvailableCarsResponse, error) {
 	query := `
-		SELECT id, brand, description, color, year, price, is_used, owner_id
+		SELECT id, brand, description, color, year, price, false, owner_id
 		FROM car
-		WHERE is_used = false
+		WHERE NOT ` + isUsedColumn + `
 	`
 	rows, err := s.db.QueryContext(ctx, query)
 	if err != nil {
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"context"
+	"log"
+	pb "miracle/proto"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	maxMessageLength        = 2000
+	defaultConversationPage = 20
+	maxConversationPage     = 100
+	subscriberBuffer        = 16
+)
+
+// messageHub fans new messages out to the SubscribeMessages streams of this
+// process. Each user may have several streams open, one per client session.
+type messageHub struct {
+	mu          sync.Mutex
+	subscribers map[int32]map[chan *pb.ChatMessage]struct{}
+}
+
+func newMessageHub() *messageHub {
+	return &messageHub{subscribers: make(map[int32]map[chan *pb.ChatMessage]struct{})}
+}
+
+func (h *messageHub) subscribe(userID int32) chan *pb.ChatMessage {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	ch := make(chan *pb.ChatMessage, subscriberBuffer)
+	if h.subscribers[userID] == nil {
+		h.subscribers[userID] = make(map[chan *pb.ChatMessage]struct{})
+	}
+	h.subscribers[userID][ch] = struct{}{}
+
+	return ch
+}
+
+func (h *messageHub) unsubscribe(userID int32, ch chan *pb.ChatMessage) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.remove(userID, ch)
+}
+
+// remove must be called with the hub locked.
+func (h *messageHub) remove(userID int32, ch chan *pb.ChatMessage) {
+	if _, ok := h.subscribers[userID][ch]; !ok {
+		return
+	}
+
+	delete(h.subscribers[userID], ch)
+	if len(h.subscribers[userID]) == 0 {
+		delete(h.subscribers, userID)
+	}
+	close(ch)
+}
+
+// publish delivers the message to the streams of both participants. A stream
+// that has fallen a full buffer behind is dropped rather than allowed to block
+// senders; its client catches up through ListConversation after reconnecting.
+func (h *messageHub) publish(msg *pb.ChatMessage) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, userID := range []int32{msg.SenderId, msg.ReceiverId} {
+		for ch := range h.subscribers[userID] {
+			select {
+			case ch <- msg:
+			default:
+				log.Printf("Dropping slow message subscriber for user ID: %d", userID)
+				h.remove(userID, ch)
+			}
+		}
+	}
+}
+
+func (s *server) SendMessage(ctx context.Context, req *pb.SendMessageRequest) (*pb.SendMessageResponse, error) {
+	content := strings.TrimSpace(req.Content)
+
+	switch {
+	case req.SenderId < 1 || req.ReceiverId < 1:
+		return nil, status.Error(codes.InvalidArgument, "sender and receiver must be provided")
+	case req.SenderId == req.ReceiverId:
+		return nil, status.Error(codes.InvalidArgument, "you can't send a message to yourself")
+	case content == "":
+		return nil, status.Error(codes.InvalidArgument, "message must not be empty")
+	case utf8.RuneCountInString(content) > maxMessageLength:
+		return nil, status.Errorf(codes.InvalidArgument, "message must not be more than %d characters long", maxMessageLength)
+	}
+
+	err := s.authorizeUser(ctx, req.SenderId)
+	if err != nil {
+		return nil, err
+	}
+
+	allowed, err := s.canChat(ctx, req.SenderId, req.ReceiverId)
+	if err != nil {
+		log.Printf("Failed to check chat permission: %v", err)
+		return nil, status.Error(codes.Internal, "failed to send message")
+	}
+	if !allowed {
+		return nil, status.Error(codes.PermissionDenied, "messages can only be exchanged between a renter and the owner of the car")
+	}
+
+	msg := &pb.ChatMessage{
+		SenderId:   req.SenderId,
+		ReceiverId: req.ReceiverId,
+		Content:    content,
+	}
+
+	var createdAt time.Time
+	err = s.db.QueryRowContext(ctx, `
+		INSERT INTO messages (sender_id, receiver_id, content)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at`,
+		msg.SenderId, msg.ReceiverId, msg.Content).Scan(&msg.MessageId, &createdAt)
+	if err != nil {
+		log.Printf("Failed to store message: %v", err)
+		return nil, status.Error(codes.Internal, "failed to send message")
+	}
+	msg.Timestamp = timestamppb.New(createdAt)
+
+	s.hub.publish(msg)
+
+	response := &pb.SendMessageResponse{
+		MessageId: msg.MessageId,
+		Timestamp: msg.Timestamp,
+	}
+
+	return response, nil
+}
+
+// canChat reports whether one of the users has rented a car owned by the
+// other.
+func (s *server) canChat(ctx context.Context, a, b int32) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM rented_cars r
+			JOIN car c ON c.id = r.car_id
+			WHERE (r.user_id = $1 AND c.owner_id = $2)
+			OR (r.user_id = $2 AND c.owner_id = $1))`
+
+	var allowed bool
+	err := s.db.QueryRowContext(ctx, query, a, b).Scan(&allowed)
+	return allowed, err
+}
+
+func (s *server) ListConversation(ctx context.Context, req *pb.ListConversationRequest) (*pb.ListConversationResponse, error) {
+	switch {
+	case req.UserId < 1 || req.PeerId < 1:
+		return nil, status.Error(codes.InvalidArgument, "user and peer must be provided")
+	case req.BeforeId < 0:
+		return nil, status.Error(codes.InvalidArgument, "before_id must not be negative")
+	case req.PageSize < 0 || req.PageSize > maxConversationPage:
+		return nil, status.Errorf(codes.InvalidArgument, "page_size must be between 1 and %d", maxConversationPage)
+	}
+
+	err := s.authorizeUser(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := req.PageSize
+	if pageSize == 0 {
+		pageSize = defaultConversationPage
+	}
+
+	low, high := req.UserId, req.PeerId
+	if low > high {
+		low, high = high, low
+	}
+
+	query := `
+		SELECT id, sender_id, receiver_id, content, created_at
+		FROM messages
+		WHERE LEAST(sender_id, receiver_id) = $1 AND GREATEST(sender_id, receiver_id) = $2
+		AND (id < $3 OR $3 = 0)
+		ORDER BY id DESC
+		LIMIT $4`
+
+	rows, err := s.db.QueryContext(ctx, query, low, high, req.BeforeId, pageSize+1)
+	if err != nil {
+		log.Printf("Failed to list conversation: %v", err)
+		return nil, status.Error(codes.Internal, "failed to list conversation")
+	}
+	defer rows.Close()
+
+	var messages []*pb.ChatMessage
+	for rows.Next() {
+		var msg pb.ChatMessage
+		var createdAt time.Time
+		err := rows.Scan(&msg.MessageId, &msg.SenderId, &msg.ReceiverId, &msg.Content, &createdAt)
+		if err != nil {
+			log.Printf("Failed to scan message: %v", err)
+			return nil, status.Error(codes.Internal, "failed to list conversation")
+		}
+		msg.Timestamp = timestamppb.New(createdAt)
+		messages = append(messages, &msg)
+	}
+
+	if err = rows.Err(); err != nil {
+		log.Printf("Failed to list conversation: %v", err)
+		return nil, status.Error(codes.Internal, "failed to list conversation")
+	}
+
+	response := &pb.ListConversationResponse{Messages: messages}
+
+	if len(messages) > int(pageSize) {
+		response.Messages = messages[:pageSize]
+		response.NextBeforeId = messages[pageSize-1].MessageId
+	}
+
+	return response, nil
+}
+
+func (s *server) SubscribeMessages(req *pb.SubscribeMessagesRequest, stream pb.UserService_SubscribeMessagesServer) error {
+	if req.UserId < 1 {
+		return status.Error(codes.InvalidArgument, "user must be provided")
+	}
+
+	err := s.authorizeUser(stream.Context(), req.UserId)
+	if err != nil {
+		return err
+	}
+
+	ch := s.hub.subscribe(req.UserId)
+	defer s.hub.unsubscribe(req.UserId, ch)
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case msg, ok := <-ch:
+			if !ok {
+				return status.Error(codes.ResourceExhausted, "subscriber fell behind, reconnect and list the conversation")
+			}
+
+			err := stream.Send(msg)
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
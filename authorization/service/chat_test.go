@@ -0,0 +1,48 @@
+package main
+
+import (
+	pb "miracle/proto"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessageHub_Publish(t *testing.T) {
+	hub := newMessageHub()
+
+	sender := hub.subscribe(1)
+	receiver := hub.subscribe(2)
+	other := hub.subscribe(3)
+
+	msg := &pb.ChatMessage{MessageId: 1, SenderId: 1, ReceiverId: 2, Content: "hello"}
+	hub.publish(msg)
+
+	assert.Equal(t, msg, <-sender)
+	assert.Equal(t, msg, <-receiver)
+	assert.Empty(t, other)
+
+	hub.unsubscribe(2, receiver)
+	_, ok := <-receiver
+	assert.False(t, ok)
+
+	hub.publish(msg)
+	assert.Equal(t, msg, <-sender)
+}
+
+func TestMessageHub_DropsSlowSubscriber(t *testing.T) {
+	hub := newMessageHub()
+	ch := hub.subscribe(2)
+
+	for i := 0; i <= subscriberBuffer; i++ {
+		hub.publish(&pb.ChatMessage{MessageId: int32(i + 1), SenderId: 1, ReceiverId: 2})
+	}
+
+	received := 0
+	for range ch {
+		received++
+	}
+	assert.Equal(t, subscriberBuffer, received)
+
+	hub.unsubscribe(2, ch)
+	assert.Empty(t, hub.subscribers)
+}
@@ -44,16 +44,6 @@ func generateUniqueIDforCar(db *sql.DB) (int32, error) {
 	return id, nil
 }
 
-func generateUniqueIDforRent(db *sql.DB) (int32, error) {
-	var id int32
-	err := db.QueryRow("SELECT COALESCE(MAX(id), 0) + 1 FROM rented_cars").Scan(&id)
-	if err != nil {
-		log.Printf("Failed to generate unique ID: %v", err)
-		return 0, err
-	}
-	return id, nil
-}
-
 func hashPassword(password string) (string, error) {
 	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	if err != nil {
@@ -61,3 +51,10 @@ func hashPassword(password string) (string, error) {
 	}
 	return string(hash), nil
 }
+
+// notifyEvents wakes the outbox relay after a commit that enqueued events.
+func (s *server) notifyEvents() {
+	if s.relay != nil {
+		s.relay.Notify()
+	}
+}
@@ -132,8 +132,8 @@ func TestServer_RentCar(t *testing.T) {
 
 	carID := int32(5)
 	_, err = db.ExecContext(ctx, `
-		INSERT INTO car (id, brand, owner_id, description, color, year, price, is_used)
-		VALUES ($1, 'Toyota', $2, 'Sedan', 'Red', 2022, 20000, false)`,
+		INSERT INTO car (id, brand, owner_id, description, color, year, price)
+		VALUES ($1, 'Toyota', $2, 'Sedan', 'Red', 2022, 20000)`,
 		carID, userID)
 	assert.NoError(t, err)
 
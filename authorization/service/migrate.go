@@ -0,0 +1,66 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"miracle/migrate"
+	"miracle/migrations"
+	"time"
+)
+
+const migrationsTable = "authorization_schema_migrations"
+
+func runMigrations(db *sql.DB, command string) error {
+	migrator := migrate.Migrator{
+		DB:    db,
+		FS:    migrations.FS,
+		Table: migrationsTable,
+	}
+
+	switch command {
+	case "up":
+		applied, err := migrator.Up()
+		for _, m := range applied {
+			log.Printf("Migration applied: %d %s", m.Version, m.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			log.Printf("No pending migrations")
+		}
+
+	case "down":
+		m, err := migrator.Down()
+		if err != nil {
+			switch {
+			case errors.Is(err, migrate.ErrNoMigrations):
+				log.Printf("No migrations to roll back")
+				return nil
+			default:
+				return err
+			}
+		}
+		log.Printf("Migration rolled back: %d %s", m.Version, m.Name)
+
+	case "status":
+		statuses, err := migrator.Status()
+		if err != nil {
+			return err
+		}
+		for _, s := range statuses {
+			appliedAt := "pending"
+			if s.AppliedAt != nil {
+				appliedAt = s.AppliedAt.Format(time.RFC3339)
+			}
+			log.Printf("Migration %d %s: %s", s.Version, s.Name, appliedAt)
+		}
+
+	default:
+		return fmt.Errorf("unknown migrate command %q (expected up, down or status)", command)
+	}
+
+	return nil
+}
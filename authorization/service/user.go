@@ -6,7 +6,9 @@ import (
 	"fmt"
 	"golang.org/x/crypto/bcrypt"
 	"log"
+	"miracle/events"
 	pb "miracle/proto"
+	"time"
 )
 
 func (s *server) RegisterUser(ctx context.Context, req *pb.RegisterRequest) (*pb.RegisterResponse, error) {
@@ -34,7 +36,14 @@ func (s *server) RegisterUser(ctx context.Context, req *pb.RegisterRequest) (*pb
 		return nil, fmt.Errorf("failed to register user")
 	}
 
-	_, err = s.db.ExecContext(ctx, `
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("Failed to begin transaction: %v", err)
+		return nil, fmt.Errorf("failed to register user")
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
 		INSERT INTO users (id, name, surname, email, password_hash, owned_car, rented_car)
 		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
 		userID, req.Name, req.Surname, req.Email, passwordHash, 0, 0)
@@ -43,6 +52,24 @@ func (s *server) RegisterUser(ctx context.Context, req *pb.RegisterRequest) (*pb
 		return nil, fmt.Errorf("failed to register user")
 	}
 
+	err = events.Enqueue(ctx, tx, events.TypeUserRegistered, events.UserRegistered{
+		UserID:  userID,
+		Name:    req.Name,
+		Surname: req.Surname,
+		Email:   req.Email,
+	})
+	if err != nil {
+		log.Printf("Failed to enqueue event: %v", err)
+		return nil, fmt.Errorf("failed to register user")
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		log.Printf("Failed to commit registration: %v", err)
+		return nil, fmt.Errorf("failed to register user")
+	}
+	s.notifyEvents()
+
 	response := &pb.RegisterResponse{
 		UserId: userID,
 	}
@@ -51,31 +78,65 @@ func (s *server) RegisterUser(ctx context.Context, req *pb.RegisterRequest) (*pb
 }
 
 func (s *server) LoginUser(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResponse, error) {
+	ipFailures, err := s.countIPFailures(ctx, peerIP(ctx))
+	if err != nil {
+		log.Printf("Failed to count failed logins: %v", err)
+		return nil, fmt.Errorf("failed to login")
+	}
+
+	if s.lockout.ipMaxFailures > 0 && ipFailures >= s.lockout.ipMaxFailures {
+		return nil, fmt.Errorf("too many failed login attempts, please try again later")
+	}
+
 	query := "SELECT COUNT(*) FROM users WHERE email = $1"
 	var count int
-	err := s.db.QueryRowContext(ctx, query, req.Email).Scan(&count)
+	err = s.db.QueryRowContext(ctx, query, req.Email).Scan(&count)
 	if err != nil {
 		log.Printf("Failed to check email existence: %v", err)
 		return nil, fmt.Errorf("failed to login")
 	}
 
 	if count == 0 {
-		return nil, fmt.Errorf("email '%s' does not exist", req.Email)
+		s.recordLoginAttempt(ctx, nil, req.Email, false)
+		s.delayFailedLogin(ctx, ipFailures+1)
+		return nil, errInvalidCredentials
 	}
 
 	var userID int32
 	var passwordHash string
-	err = s.db.QueryRowContext(ctx, "SELECT id, password_hash FROM users WHERE email = $1", req.Email).Scan(&userID, &passwordHash)
+	var failedLogins int
+	var lockedUntil *time.Time
+	err = s.db.QueryRowContext(ctx, "SELECT id, password_hash, failed_logins, locked_until FROM users WHERE email = $1", req.Email).Scan(&userID, &passwordHash, &failedLogins, &lockedUntil)
 	if err != nil {
 		log.Printf("Failed to retrieve user data: %v", err)
 		return nil, fmt.Errorf("failed to login")
 	}
 
+	if lockedUntil != nil && lockedUntil.After(time.Now()) {
+		s.recordLoginAttempt(ctx, &userID, req.Email, false)
+		s.delayFailedLogin(ctx, failedLogins)
+		return nil, errInvalidCredentials
+	}
+
 	err = bcrypt.CompareHashAndPassword([]byte(passwordHash), []byte(req.Password))
 	if err != nil {
 		log.Printf("Password does not match: %v", err)
-		return nil, fmt.Errorf("incorrect password")
+		s.recordLoginAttempt(ctx, &userID, req.Email, false)
+
+		failures, err := s.recordFailedLogin(ctx, userID)
+		if err != nil {
+			log.Printf("Failed to record failed login: %v", err)
+		}
+
+		s.delayFailedLogin(ctx, failures)
+		return nil, errInvalidCredentials
+	}
+
+	_, err = s.db.ExecContext(ctx, "UPDATE users SET failed_logins = 0, locked_until = NULL WHERE id = $1", userID)
+	if err != nil {
+		log.Printf("Failed to reset failed logins: %v", err)
 	}
+	s.recordLoginAttempt(ctx, &userID, req.Email, true)
 
 	response := &pb.LoginResponse{
 		UserId: userID,
@@ -139,7 +200,7 @@ func (s *server) fetchOwnedCar(ctx context.Context, userID int32) ([]*pb.Car, er
 }
 
 func (s *server) fetchRentedCar(ctx context.Context, userID int32) ([]*pb.Car, error) {
-	query := "SELECT c.id, c.brand, c.description FROM car c JOIN rented_cars r ON c.id = r.car_id WHERE r.user_id = $1"
+	query := "SELECT c.id, c.brand, c.description FROM car c JOIN rented_cars r ON c.id = r.car_id WHERE r.user_id = $1 AND r.status = 'active'"
 	rows, err := s.db.QueryContext(ctx, query, userID)
 	if err != nil {
 		log.Printf("Failed to fetch rented car for user ID: %d", userID)
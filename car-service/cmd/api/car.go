@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 )
 
 func (app *application) createCarHandler(w http.ResponseWriter, r *http.Request) {
@@ -16,7 +17,6 @@ func (app *application) createCarHandler(w http.ResponseWriter, r *http.Request)
 		Color       string `json:"color,omitempty"`
 		Year        int32  `json:"year,omitempty"`
 		Price       int32  `json:"price"`
-		UserID      int64  `json:"user_id"`
 	}
 
 	err := app.readJSON(w, r, &input)
@@ -31,7 +31,7 @@ func (app *application) createCarHandler(w http.ResponseWriter, r *http.Request)
 		Color:       input.Color,
 		Year:        input.Year,
 		Price:       input.Price,
-		OwnerID:     input.UserID,
+		OwnerID:     app.contextGetPrincipal(r).UserID,
 	}
 
 	v := validator.New()
@@ -74,12 +74,24 @@ func (app *application) showCarHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	err = app.attachImages(car)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
 	err = app.writeJSON(w, http.StatusOK, envelope{"car": car}, nil)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
 	}
 }
 
+// Errors returned from the update callback to abort a car update.
+var (
+	errWrongCar   = errors.New("car belongs to another user")
+	errInvalidCar = errors.New("invalid car")
+)
+
 func (app *application) updateCarHandler(w http.ResponseWriter, r *http.Request) {
 	id, err := app.readIDParam(r)
 	if err != nil {
@@ -87,24 +99,12 @@ func (app *application) updateCarHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	car, err := app.models.Car.Get(id)
-	if err != nil {
-		switch {
-		case errors.Is(err, model.ErrRecordNotFound):
-			app.notFoundResponse(w, r)
-		default:
-			app.serverErrorResponse(w, r, err)
-		}
-		return
-	}
-
 	var input struct {
 		Brand       *string `json:"brand"`
 		Description *string `json:"description"`
 		Color       *string `json:"color"`
 		Year        *int32  `json:"year"`
 		Price       *int32  `json:"price"`
-		IsUsed      *bool   `json:"is_used"`
 	}
 
 	err = app.readJSON(w, r, &input)
@@ -113,60 +113,99 @@ func (app *application) updateCarHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	if input.Brand != nil {
-		car.Brand = *input.Brand
-	}
+	principal := app.contextGetPrincipal(r)
+	v := validator.New()
 
-	if input.Description != nil {
-		car.Description = *input.Description
-	}
+	car, err := app.models.Car.Update(id, principal.UserID, func(car *model.Car) error {
+		if car.OwnerID != principal.UserID && !principal.HasPermission(model.PermissionCarsModerate) {
+			return errWrongCar
+		}
+
+		if input.Brand != nil {
+			car.Brand = *input.Brand
+		}
+
+		if input.Description != nil {
+			car.Description = *input.Description
+		}
+
+		if input.Color != nil {
+			car.Color = *input.Color
+		}
+
+		if input.Year != nil {
+			car.Year = *input.Year
+		}
 
-	if input.Color != nil {
-		car.Color = *input.Color
+		if input.Price != nil {
+			car.Price = *input.Price
+		}
+
+		if model.ValidateCar(v, car); !v.Valid() {
+			return errInvalidCar
+		}
+
+		return nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, model.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		case errors.Is(err, errWrongCar):
+			app.wrongCarResponse(w, r)
+		case errors.Is(err, errInvalidCar):
+			app.failedValidationResponse(w, r, v.Errors)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
 	}
 
-	if input.Year != nil {
-		car.Year = *input.Year
+	err = app.writeJSON(w, http.StatusOK, envelope{"car": car}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
 	}
+}
 
-	if input.Price != nil {
-		car.Price = *input.Price
+// listCarAuditHandler shows who changed which fields of the car. Like the
+// changes themselves, it is limited to the owner and moderators.
+func (app *application) listCarAuditHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := app.readIDParam(r)
+	if err != nil {
+		app.notFoundResponse(w, r)
+		return
 	}
 
-	if input.IsUsed != nil {
-		car.IsUsed = *input.IsUsed
+	car, err := app.models.Car.Get(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, model.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
 	}
 
-	v := validator.New()
-	if model.ValidateCar(v, car); !v.Valid() {
-		app.failedValidationResponse(w, r, v.Errors)
+	principal := app.contextGetPrincipal(r)
+	if car.OwnerID != principal.UserID && !principal.HasPermission(model.PermissionCarsModerate) {
+		app.wrongCarResponse(w, r)
 		return
 	}
 
-	err = app.models.Car.Update(car)
+	audit, err := app.models.Audit.GetForCar(id)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
 		return
 	}
 
-	err = app.writeJSON(w, http.StatusOK, envelope{"car": car}, nil)
+	err = app.writeJSON(w, http.StatusOK, envelope{"audit": audit}, nil)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
 	}
 }
 
 func (app *application) deleteCarHandler(w http.ResponseWriter, r *http.Request) {
-	var input struct {
-		UserID   int64  `json:"user_id"`
-		UserRole string `json:"user_role"`
-	}
-
-	err := app.readJSON(w, r, &input)
-	if err != nil {
-		app.badRequestResponse(w, r, err)
-		return
-	}
-
 	id, err := app.readIDParam(r)
 	if err != nil {
 		app.notFoundResponse(w, r)
@@ -184,10 +223,15 @@ func (app *application) deleteCarHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	if input.UserRole != "MODERATOR" {
-		if car.OwnerID != input.UserID {
-			app.wrongCarResponse(w, r)
-		}
+	principal := app.contextGetPrincipal(r)
+	if car.OwnerID != principal.UserID && !principal.HasPermission(model.PermissionCarsModerate) {
+		app.wrongCarResponse(w, r)
+		return
+	}
+
+	images, err := app.models.Image.GetForCars([]int64{id})
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
 		return
 	}
 
@@ -202,6 +246,10 @@ func (app *application) deleteCarHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	app.background(func() {
+		app.deleteImageBlobs(images[id])
+	})
+
 	err = app.writeJSON(w, http.StatusOK, envelope{"message": "car successfully deleted"}, nil)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
@@ -210,8 +258,7 @@ func (app *application) deleteCarHandler(w http.ResponseWriter, r *http.Request)
 
 func (app *application) listCarHandler(w http.ResponseWriter, r *http.Request) {
 	var input struct {
-		Brand string
-		Color string
+		model.CarSearch
 		data.Filters
 	}
 
@@ -221,19 +268,48 @@ func (app *application) listCarHandler(w http.ResponseWriter, r *http.Request) {
 
 	input.Brand = app.readString(qs, "brand", "")
 	input.Color = app.readString(qs, "color", "")
+	input.Query = app.readString(qs, "q", "")
+
+	input.MinPrice = int32(app.readInt(qs, "min_price", 0, v))
+	input.MaxPrice = int32(app.readInt(qs, "max_price", 0, v))
+	input.MinYear = int32(app.readInt(qs, "min_year", 0, v))
+	input.MaxYear = int32(app.readInt(qs, "max_year", 0, v))
+	input.OwnerID = int64(app.readInt(qs, "owner_id", 0, v))
+	input.Available = app.readBool(qs, "available", false, v)
+
+	// Full-text matches come best first unless another order is asked for.
+	defaultSort := "id"
+	if input.Query != "" {
+		defaultSort = "-rank"
+	}
 
 	input.Filters.Page = app.readInt(qs, "page", 1, v)
 	input.Filters.PageSize = app.readInt(qs, "page_size", 20, v)
 
-	input.Filters.Sort = app.readString(qs, "sort", "id")
-	input.Filters.SortSafeList = []string{"id", "brand", "year", "price", "is_used", "-id", "-brand", "-year", "-price", "-is_used"}
+	input.Filters.Sort = app.readString(qs, "sort", defaultSort)
+	input.Filters.SortSafeList = []string{"id", "brand", "year", "price", "is_used", "rank", "-id", "-brand", "-year", "-price", "-is_used", "-rank"}
+	input.Filters.Cursor = app.readCursor(qs, "cursor", v)
+
+	model.ValidateCarSearch(v, input.CarSearch)
 
 	if data.ValidateFilters(v, input.Filters); !v.Valid() {
 		app.failedValidationResponse(w, r, v.Errors)
 		return
 	}
 
-	car, metadata, err := app.models.Car.GetAll(input.Brand, input.Color, input.Filters)
+	car, metadata, err := app.models.Car.GetAll(input.CarSearch, input.Filters)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrInvalidCursor):
+			v.AddError("cursor", "is invalid")
+			app.failedValidationResponse(w, r, v.Errors)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	err = app.attachImages(car...)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
 		return
@@ -247,7 +323,8 @@ func (app *application) listCarHandler(w http.ResponseWriter, r *http.Request) {
 
 func (app *application) rentCarHandler(w http.ResponseWriter, r *http.Request) {
 	var input struct {
-		UserID int64 `json:"user_id"`
+		TakingDate *time.Time `json:"taking_date"`
+		ReturnDate time.Time  `json:"return_date"`
 	}
 
 	err := app.readJSON(w, r, &input)
@@ -273,41 +350,93 @@ func (app *application) rentCarHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if car.IsUsed != false {
-		app.carOccupiedResponse(w, r)
-		return
+	rental := &model.Rental{
+		UserID:     app.contextGetPrincipal(r).UserID,
+		CarID:      car.ID,
+		TakingDate: time.Now(),
+		ReturnDate: input.ReturnDate,
 	}
 
-	err = app.models.Car.InsertToRent(car, input.UserID)
-	if err != nil {
-		app.serverErrorResponse(w, r, err)
+	if input.TakingDate != nil {
+		rental.TakingDate = *input.TakingDate
+	}
+
+	v := validator.New()
+
+	if model.ValidateRental(v, rental); !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
 		return
 	}
 
-	car.IsUsed = true
-	err = app.models.Car.Update(car)
+	err = app.models.Rental.Rent(rental)
 	if err != nil {
-		app.serverErrorResponse(w, r, err)
+		switch {
+		case errors.Is(err, model.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		case errors.Is(err, model.ErrCarOccupied):
+			app.carOccupiedResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
 		return
 	}
 
-	err = app.writeJSON(w, http.StatusOK, envelope{"car": car}, nil)
+	if !rental.TakingDate.After(time.Now()) {
+		car.IsUsed = true
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"car": car, "rental": rental}, nil)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
 	}
 }
 
-func (app *application) returnRentedCarHandler(w http.ResponseWriter, r *http.Request) {
-	var input struct {
-		UserID int64 `json:"user_id"`
+func (app *application) showCarAvailabilityHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := app.readIDParam(r)
+	if err != nil {
+		app.notFoundResponse(w, r)
+		return
 	}
 
-	err := app.readJSON(w, r, &input)
+	v := validator.New()
+
+	qs := r.URL.Query()
+
+	from := app.readTime(qs, "from", time.Now(), v)
+	to := app.readTime(qs, "to", from.AddDate(0, 0, 30), v)
+
+	v.Check(to.After(from), "to", "must be after from")
+	v.Check(!to.After(from.AddDate(1, 0, 0)), "to", "must not be more than a year after from")
+
+	if !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	_, err = app.models.Car.Get(id)
 	if err != nil {
-		app.badRequestResponse(w, r, err)
+		switch {
+		case errors.Is(err, model.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
 		return
 	}
 
+	availability, err := app.models.Rental.GetAvailability(id, from, to)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"availability": availability}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) returnRentedCarHandler(w http.ResponseWriter, r *http.Request) {
 	id, err := app.readIDParam(r)
 	if err != nil {
 		app.notFoundResponse(w, r)
@@ -325,16 +454,13 @@ func (app *application) returnRentedCarHandler(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	if car.IsUsed != true {
-		app.carNotUsedResponse(w, r)
-		return
-	}
-
-	err = app.models.Car.DeleteFromRent(car, input.UserID)
+	rental, invoice, err := app.models.Rental.Return(car.ID, app.contextGetPrincipal(r).UserID, app.config.pricing)
 	if err != nil {
 		switch {
 		case errors.Is(err, model.ErrRecordNotFound):
 			app.notFoundResponse(w, r)
+		case errors.Is(err, model.ErrCarNotUsed):
+			app.carNotUsedResponse(w, r)
 		default:
 			app.serverErrorResponse(w, r, err)
 		}
@@ -342,13 +468,8 @@ func (app *application) returnRentedCarHandler(w http.ResponseWriter, r *http.Re
 	}
 
 	car.IsUsed = false
-	err = app.models.Car.Update(car)
-	if err != nil {
-		app.serverErrorResponse(w, r, err)
-		return
-	}
 
-	err = app.writeJSON(w, http.StatusOK, envelope{"car": car}, nil)
+	err = app.writeJSON(w, http.StatusOK, envelope{"car": car, "rental": rental, "invoice": invoice}, nil)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
 	}
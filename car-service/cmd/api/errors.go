@@ -61,3 +61,23 @@ func (app *application) carNotUsedResponse(w http.ResponseWriter, r *http.Reques
 	message := "this car is not used"
 	app.errorResponse(w, r, http.StatusForbidden, message)
 }
+
+func (app *application) invalidPrincipalResponse(w http.ResponseWriter, r *http.Request) {
+	message := "invalid or expired principal"
+	app.errorResponse(w, r, http.StatusUnauthorized, message)
+}
+
+func (app *application) authenticationRequiredResponse(w http.ResponseWriter, r *http.Request) {
+	message := "you must be authenticated to access this resource"
+	app.errorResponse(w, r, http.StatusUnauthorized, message)
+}
+
+func (app *application) rateLimitExceededResponse(w http.ResponseWriter, r *http.Request) {
+	message := "rate limit exceeded"
+	app.errorResponse(w, r, http.StatusTooManyRequests, message)
+}
+
+func (app *application) fileSizeLimitResponse(w http.ResponseWriter, r *http.Request) {
+	message := "the file exceeds the maximum allowed memory size"
+	app.errorResponse(w, r, http.StatusNotAcceptable, message)
+}
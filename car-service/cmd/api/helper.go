@@ -1,6 +1,7 @@
 package main
 
 import (
+	"car-service/internal/data"
 	"car-service/internal/validator"
 	"encoding/json"
 	"errors"
@@ -11,6 +12,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type envelope map[string]any
@@ -121,6 +123,52 @@ func (app *application) readInt(qs url.Values, key string, defaultValue int, v *
 	return i
 }
 
+func (app *application) readBool(qs url.Values, key string, defaultValue bool, v *validator.Validator) bool {
+	s := qs.Get(key)
+	if s == "" {
+		return defaultValue
+	}
+
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		v.AddError(key, "must be a boolean value")
+		return defaultValue
+	}
+
+	return b
+}
+
+// readCursor returns nil unless the key is present in the query string, so
+// that an empty cursor can ask for the first page in cursor mode.
+func (app *application) readCursor(qs url.Values, key string, v *validator.Validator) *data.Cursor {
+	if !qs.Has(key) {
+		return nil
+	}
+
+	cursor, err := data.DecodeCursor(qs.Get(key))
+	if err != nil {
+		v.AddError(key, "is invalid")
+		return nil
+	}
+
+	return cursor
+}
+
+func (app *application) readTime(qs url.Values, key string, defaultValue time.Time, v *validator.Validator) time.Time {
+	s := qs.Get(key)
+	if s == "" {
+		return defaultValue
+	}
+
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		v.AddError(key, "must be a RFC3339 timestamp")
+		return defaultValue
+	}
+
+	return t
+}
+
 func (app *application) background(fn func()) {
 	app.wg.Add(1)
 
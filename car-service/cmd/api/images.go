@@ -0,0 +1,280 @@
+package main
+
+import (
+	"bytes"
+	"car-service/internal/blob"
+	"car-service/internal/model"
+	"car-service/internal/thumbnail"
+	"car-service/internal/validator"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// maxImagePixels guards against images that are small on disk but expand to
+// an enormous bitmap once decoded.
+const maxImagePixels = 50_000_000
+
+func (app *application) uploadCarImageHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := app.readIDParam(r)
+	if err != nil {
+		app.notFoundResponse(w, r)
+		return
+	}
+
+	car, err := app.models.Car.Get(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, model.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	// Check ownership before the form is parsed, so others can't make the
+	// server buffer uploads for cars they don't own.
+	if car.OwnerID != app.contextGetPrincipal(r).UserID {
+		app.wrongCarResponse(w, r)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, app.config.images.maxBytes+1_048_576)
+
+	err = r.ParseMultipartForm(1_048_576)
+	if err != nil {
+		var maxBytesError *http.MaxBytesError
+		switch {
+		case errors.As(err, &maxBytesError):
+			app.fileSizeLimitResponse(w, r)
+		default:
+			app.badRequestResponse(w, r, err)
+		}
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	v := validator.New()
+
+	file, header, err := r.FormFile("image")
+	if err != nil {
+		switch {
+		case errors.Is(err, http.ErrMissingFile):
+			v.AddError("image", "must be provided")
+		default:
+			app.badRequestResponse(w, r, err)
+			return
+		}
+	}
+
+	if !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+	defer file.Close()
+
+	if header.Size > app.config.images.maxBytes {
+		app.fileSizeLimitResponse(w, r)
+		return
+	}
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	config, format, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil {
+		v.AddError("image", "must be a JPEG, PNG or GIF image")
+	} else {
+		v.Check(config.Width*config.Height <= maxImagePixels, "image", "must not be larger than 50 megapixels")
+	}
+
+	if !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	name, err := randomName()
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	img := &model.Image{
+		CarID:       car.ID,
+		ContentType: "image/" + format,
+		Key:         fmt.Sprintf("cars/%d/%s.%s", car.ID, name, format),
+	}
+
+	err = app.blobs.Put(img.Key, bytes.NewReader(content))
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.models.Image.Insert(img)
+	if err != nil {
+		app.blobs.Delete(img.Key)
+
+		switch {
+		case errors.Is(err, model.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	app.background(func() {
+		app.generateThumbnail(img.ID, img.Key, content)
+	})
+
+	app.setImageURLs(img)
+
+	headers := make(http.Header)
+	headers.Set("Location", fmt.Sprintf("/car/%d", car.ID))
+
+	err = app.writeJSON(w, http.StatusCreated, envelope{"image": img}, headers)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) showImageHandler(w http.ResponseWriter, r *http.Request) {
+	params := httprouter.ParamsFromContext(r.Context())
+	key := strings.TrimPrefix(params.ByName("filepath"), "/")
+
+	file, err := app.blobs.Open(key)
+	if err != nil {
+		switch {
+		case errors.Is(err, blob.ErrNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+	defer file.Close()
+
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Cache-Control", "public, max-age=86400, immutable")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+
+	_, err = io.Copy(w, file)
+	if err != nil {
+		app.logError(r, err)
+	}
+}
+
+// generateThumbnail stores a JPEG thumbnail next to the original image and
+// records its key. Failures are only logged: the image stays usable without a
+// thumbnail.
+func (app *application) generateThumbnail(imageID int64, key string, content []byte) {
+	properties := map[string]string{"image_id": strconv.FormatInt(imageID, 10)}
+
+	src, _, err := image.Decode(bytes.NewReader(content))
+	if err != nil {
+		app.logger.PrintError(err, properties)
+		return
+	}
+
+	var buf bytes.Buffer
+
+	err = jpeg.Encode(&buf, thumbnail.Generate(src, app.config.images.thumbnailSize), &jpeg.Options{Quality: 80})
+	if err != nil {
+		app.logger.PrintError(err, properties)
+		return
+	}
+
+	thumbnailKey := strings.TrimSuffix(key, path.Ext(key)) + "_thumb.jpg"
+
+	err = app.blobs.Put(thumbnailKey, &buf)
+	if err != nil {
+		app.logger.PrintError(err, properties)
+		return
+	}
+
+	err = app.models.Image.SetThumbnail(imageID, thumbnailKey)
+	if err != nil {
+		app.blobs.Delete(thumbnailKey)
+		app.logger.PrintError(err, properties)
+	}
+}
+
+// deleteImageBlobs removes the stored files of images whose rows are gone.
+func (app *application) deleteImageBlobs(images []*model.Image) {
+	for _, img := range images {
+		for _, key := range []string{img.Key, img.ThumbnailKey} {
+			if key == "" {
+				continue
+			}
+
+			err := app.blobs.Delete(key)
+			if err != nil {
+				app.logger.PrintError(err, map[string]string{"key": key})
+			}
+		}
+	}
+}
+
+// attachImages loads the images of the cars with a single query and fills in
+// their URLs.
+func (app *application) attachImages(cars ...*model.Car) error {
+	ids := make([]int64, 0, len(cars))
+	for _, car := range cars {
+		ids = append(ids, car.ID)
+	}
+
+	images, err := app.models.Image.GetForCars(ids)
+	if err != nil {
+		return err
+	}
+
+	for _, car := range cars {
+		car.Images = images[car.ID]
+		for _, img := range car.Images {
+			app.setImageURLs(img)
+		}
+	}
+
+	return nil
+}
+
+func (app *application) setImageURLs(img *model.Image) {
+	img.URL = app.blobs.URL(img.Key)
+	if img.ThumbnailKey != "" {
+		img.ThumbnailURL = app.blobs.URL(img.ThumbnailKey)
+	}
+}
+
+func randomName() (string, error) {
+	b := make([]byte, 16)
+
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"car-service/internal/principal"
+	"car-service/internal/ratelimit"
+	"net/http"
+)
+
+// authenticate verifies the principal the gateway signed into the request, if
+// any, and puts it in the request context.
+func (app *application) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		value := r.Header.Get(principal.Header)
+		if value == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		p, err := principal.Verify(value, []byte(app.config.principal.secret))
+		if err != nil {
+			app.invalidPrincipalResponse(w, r)
+			return
+		}
+
+		next.ServeHTTP(w, app.contextSetPrincipal(r, p))
+	})
+}
+
+func (app *application) requirePrincipal(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if app.contextGetPrincipal(r) == nil {
+			app.authenticationRequiredResponse(w, r)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	}
+}
+
+// rateLimit applies the default limit, or the one configured for the matching
+// route, to each client IP separately.
+func (app *application) rateLimit(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if app.limiter == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		limit := ratelimit.Limit{RPS: app.config.limiter.rps, Burst: app.config.limiter.burst}
+		key := "*"
+
+		if route, ok := app.config.limiter.routes.Match(r.Method, r.URL.Path); ok {
+			limit = route.Limit
+			key = route.Key()
+		}
+
+		if limit.Unlimited() {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ip := ratelimit.ClientIP(r, app.config.limiter.trustedProxies)
+
+		result := app.limiter.Allow(key+"|"+ip, limit)
+		ratelimit.SetHeaders(w.Header(), result)
+
+		if !result.Allowed {
+			app.rateLimitExceededResponse(w, r)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
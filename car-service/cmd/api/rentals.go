@@ -0,0 +1,121 @@
+package main
+
+import (
+	"car-service/internal/data"
+	"car-service/internal/model"
+	"car-service/internal/validator"
+	"errors"
+	"net/http"
+)
+
+func (app *application) showRentalInvoiceHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := app.readIDParam(r)
+	if err != nil {
+		app.notFoundResponse(w, r)
+		return
+	}
+
+	invoice, err := app.models.Invoice.GetForRental(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, model.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	principal := app.contextGetPrincipal(r)
+	if invoice.UserID != principal.UserID && !principal.HasPermission(model.PermissionRentalsRead) {
+		app.notFoundResponse(w, r)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"invoice": invoice}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) listCarRentalsHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := app.readIDParam(r)
+	if err != nil {
+		app.notFoundResponse(w, r)
+		return
+	}
+
+	car, err := app.models.Car.Get(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, model.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	principal := app.contextGetPrincipal(r)
+	if car.OwnerID != principal.UserID && !principal.HasPermission(model.PermissionRentalsRead) {
+		app.wrongCarResponse(w, r)
+		return
+	}
+
+	app.listRentals(w, r, id, 0)
+}
+
+func (app *application) listUserRentalsHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := app.readIDParam(r)
+	if err != nil {
+		app.notFoundResponse(w, r)
+		return
+	}
+
+	principal := app.contextGetPrincipal(r)
+	if id != principal.UserID && !principal.HasPermission(model.PermissionRentalsRead) {
+		app.notFoundResponse(w, r)
+		return
+	}
+
+	app.listRentals(w, r, 0, id)
+}
+
+func (app *application) listRentals(w http.ResponseWriter, r *http.Request, carID, userID int64) {
+	var input struct {
+		Status string
+		data.Filters
+	}
+
+	v := validator.New()
+
+	qs := r.URL.Query()
+
+	input.Status = app.readString(qs, "status", "")
+
+	input.Filters.Page = app.readInt(qs, "page", 1, v)
+	input.Filters.PageSize = app.readInt(qs, "page_size", 20, v)
+
+	input.Filters.Sort = app.readString(qs, "sort", "-taking_date")
+	input.Filters.SortSafeList = []string{"id", "taking_date", "return_date", "price", "status", "-id", "-taking_date", "-return_date", "-price", "-status"}
+
+	if input.Status != "" {
+		v.Check(validator.PermittedValue(input.Status, model.RentalStatusActive, model.RentalStatusReturned), "status", "invalid status value")
+	}
+
+	if data.ValidateFilters(v, input.Filters); !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	rentals, metadata, err := app.models.Rental.GetAll(carID, userID, input.Status, input.Filters)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"rentals": rentals, "metadata": metadata}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
@@ -5,7 +5,7 @@ import (
 	"net/http"
 )
 
-func (app *application) routes() *httprouter.Router {
+func (app *application) routes() http.Handler {
 	router := httprouter.New()
 
 	router.NotFound = http.HandlerFunc(app.notFoundResponse)
@@ -13,12 +13,22 @@ func (app *application) routes() *httprouter.Router {
 
 	router.HandlerFunc(http.MethodGet, "/car/:id", app.showCarHandler)
 	router.HandlerFunc(http.MethodGet, "/cars", app.listCarHandler)
-	router.HandlerFunc(http.MethodPost, "/car", app.createCarHandler)
-	router.HandlerFunc(http.MethodPatch, "/car/:id", app.updateCarHandler)
-	router.HandlerFunc(http.MethodDelete, "/car/:id", app.deleteCarHandler)
+	router.HandlerFunc(http.MethodPost, "/car", app.requirePrincipal(app.createCarHandler))
+	router.HandlerFunc(http.MethodPatch, "/car/:id", app.requirePrincipal(app.updateCarHandler))
+	router.HandlerFunc(http.MethodDelete, "/car/:id", app.requirePrincipal(app.deleteCarHandler))
 
-	router.HandlerFunc(http.MethodPost, "/car/:id/rent", app.rentCarHandler)
-	router.HandlerFunc(http.MethodPut, "/car/:id/return", app.returnRentedCarHandler)
+	router.HandlerFunc(http.MethodGet, "/car/:id/audit", app.requirePrincipal(app.listCarAuditHandler))
 
-	return router
+	router.HandlerFunc(http.MethodPost, "/car/:id/images", app.requirePrincipal(app.uploadCarImageHandler))
+	router.HandlerFunc(http.MethodGet, "/images/*filepath", app.showImageHandler)
+
+	router.HandlerFunc(http.MethodGet, "/car/:id/availability", app.showCarAvailabilityHandler)
+	router.HandlerFunc(http.MethodPost, "/car/:id/rent", app.requirePrincipal(app.rentCarHandler))
+	router.HandlerFunc(http.MethodPut, "/car/:id/return", app.requirePrincipal(app.returnRentedCarHandler))
+
+	router.HandlerFunc(http.MethodGet, "/car/:id/rentals", app.requirePrincipal(app.listCarRentalsHandler))
+	router.HandlerFunc(http.MethodGet, "/users/:id/rentals", app.requirePrincipal(app.listUserRentalsHandler))
+	router.HandlerFunc(http.MethodGet, "/rentals/:id/invoice", app.requirePrincipal(app.showRentalInvoiceHandler))
+
+	return app.rateLimit(app.authenticate(router))
 }
@@ -1,8 +1,11 @@
 module car-service
 
-go 1.19
+go 1.21
 
 require (
+	github.com/google/uuid v1.3.0
 	github.com/julienschmidt/httprouter v1.3.0
-	github.com/lib/pq v1.10.2 // indirect
+	github.com/lib/pq v1.10.2
 )
+
+require gopkg.in/yaml.v3 v3.0.1
@@ -0,0 +1,51 @@
+package data
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor marks the last row of a page in keyset pagination: the value of the
+// sort column and the id that breaks ties. A zero Cursor asks for the first
+// page.
+type Cursor struct {
+	Sort  string `json:"s"`
+	Value string `json:"v"`
+	ID    int64  `json:"i"`
+}
+
+// NewCursor encodes the position of the row with the given sort key and id.
+func NewCursor(sort string, key any, id int64) string {
+	js, _ := json.Marshal(Cursor{Sort: sort, Value: fmt.Sprint(key), ID: id})
+	return base64.RawURLEncoding.EncodeToString(js)
+}
+
+// DecodeCursor reverses NewCursor. The empty string decodes to the zero
+// Cursor.
+func DecodeCursor(s string) (*Cursor, error) {
+	var cursor Cursor
+	if s == "" {
+		return &cursor, nil
+	}
+
+	js, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	err = json.Unmarshal(js, &cursor)
+	if err != nil || cursor.ID < 1 {
+		return nil, ErrInvalidCursor
+	}
+
+	return &cursor, nil
+}
+
+// First reports whether the cursor asks for the first page.
+func (c Cursor) First() bool {
+	return c.ID == 0
+}
@@ -11,14 +11,18 @@ type Filters struct {
 	PageSize     int
 	Sort         string
 	SortSafeList []string
+	// Cursor switches to keyset pagination when set. Page is ignored and no
+	// total count is computed.
+	Cursor *Cursor
 }
 
 type Metadata struct {
-	CurrentPage  int `json:"current_page,omitempty"`
-	PageSize     int `json:"page_size,omitempty"`
-	FirstPage    int `json:"first_page,omitempty"`
-	LastPage     int `json:"last_page,omitempty"`
-	TotalRecords int `json:"total_records,omitempty"`
+	CurrentPage  int    `json:"current_page,omitempty"`
+	PageSize     int    `json:"page_size,omitempty"`
+	FirstPage    int    `json:"first_page,omitempty"`
+	LastPage     int    `json:"last_page,omitempty"`
+	TotalRecords int    `json:"total_records,omitempty"`
+	NextCursor   string `json:"next_cursor,omitempty"`
 }
 
 func CalculateMetadata(totalRecords, page, pageSize int) Metadata {
@@ -35,6 +39,15 @@ func CalculateMetadata(totalRecords, page, pageSize int) Metadata {
 	}
 }
 
+// CalculateCursorMetadata describes a page fetched in cursor mode. next is
+// empty on the last page.
+func CalculateCursorMetadata(pageSize int, next string) Metadata {
+	return Metadata{
+		PageSize:   pageSize,
+		NextCursor: next,
+	}
+}
+
 func (f Filters) SortColumn() string {
 	for _, safeValue := range f.SortSafeList {
 		if f.Sort == safeValue {
@@ -51,6 +64,15 @@ func (f Filters) SortDirection() string {
 	return "ASC"
 }
 
+// CursorOperator is the comparison that selects the rows after the cursor in
+// the sort direction.
+func (f Filters) CursorOperator() string {
+	if strings.HasPrefix(f.Sort, "-") {
+		return "<"
+	}
+	return ">"
+}
+
 func (f Filters) Limit() int {
 	return f.PageSize
 }
@@ -66,4 +88,8 @@ func ValidateFilters(v *validator.Validator, f Filters) {
 	v.Check(f.PageSize <= 100, "page_size", "must be a maximum of 100")
 
 	v.Check(validator.PermittedValue(f.Sort, f.SortSafeList...), "sort", "invalid sort value")
+
+	if f.Cursor != nil && !f.Cursor.First() {
+		v.Check(f.Cursor.Sort == f.Sort, "cursor", "does not match the sort value")
+	}
 }
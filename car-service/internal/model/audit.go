@@ -0,0 +1,110 @@
+package model
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+)
+
+type AuditModel struct {
+	DB *sql.DB
+}
+
+// Audit records who changed which fields of a car.
+type Audit struct {
+	ID        int64                  `json:"id"`
+	CarID     int64                  `json:"car_id"`
+	UserID    int64                  `json:"user_id"`
+	CreatedAt time.Time              `json:"created_at"`
+	Changes   map[string]FieldChange `json:"changes"`
+}
+
+type FieldChange struct {
+	Old any `json:"old"`
+	New any `json:"new"`
+}
+
+// DiffCar returns the editable fields that differ between before and after,
+// keyed by their JSON name.
+func DiffCar(before, after *Car) map[string]FieldChange {
+	changes := make(map[string]FieldChange)
+
+	add := func(field string, old, new any) {
+		if old != new {
+			changes[field] = FieldChange{Old: old, New: new}
+		}
+	}
+
+	add("brand", before.Brand, after.Brand)
+	add("description", before.Description, after.Description)
+	add("color", before.Color, after.Color)
+	add("year", before.Year, after.Year)
+	add("price", before.Price, after.Price)
+
+	return changes
+}
+
+func insertAudit(ctx context.Context, tx *sql.Tx, audit *Audit) error {
+	changes, err := json.Marshal(audit.Changes)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO car_audit (car_id, user_id, changes)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at`
+
+	return tx.QueryRowContext(ctx, query, audit.CarID, audit.UserID, changes).Scan(&audit.ID, &audit.CreatedAt)
+}
+
+// GetForCar returns the audit trail of the car, newest first.
+func (m AuditModel) GetForCar(carID int64) ([]*Audit, error) {
+	query := `
+		SELECT id, car_id, user_id, created_at, changes
+		FROM car_audit
+		WHERE car_id = $1
+		ORDER BY id DESC`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := m.DB.QueryContext(ctx, query, carID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	audits := []*Audit{}
+
+	for rows.Next() {
+		var audit Audit
+		var changes []byte
+
+		err := rows.Scan(
+			&audit.ID,
+			&audit.CarID,
+			&audit.UserID,
+			&audit.CreatedAt,
+			&changes,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		err = json.Unmarshal(changes, &audit.Changes)
+		if err != nil {
+			return nil, err
+		}
+
+		audits = append(audits, &audit)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return audits, nil
+}
@@ -2,12 +2,15 @@ package model
 
 import (
 	"car-service/internal/data"
+	"car-service/internal/events"
 	"car-service/internal/validator"
 	"context"
 	"database/sql"
 	"errors"
 	"fmt"
 	"time"
+
+	"github.com/lib/pq"
 )
 
 type CarModel struct {
@@ -24,64 +27,56 @@ type Car struct {
 	Price       int32     `json:"price"`
 	IsUsed      bool      `json:"is_used"`
 	OwnerID     int64     `json:"owner_id"`
+	Images      []*Image  `json:"images,omitempty"`
 }
 
+// is_used is not stored: a car is in use while one of its active rentals has
+// started.
+const isUsedColumn = `EXISTS (
+			SELECT 1 FROM rented_cars
+			WHERE rented_cars.car_id = car.id
+			AND rented_cars.status = 'active'
+			AND rented_cars.taking_date <= now()) AS is_used`
+
 func (m CarModel) Insert(car *Car) error {
 	query := `
 		INSERT INTO car (brand, description, color, year, price, owner_id)
 		VALUES ($1, $2, $3, $4, $5, $6)
-		RETURNING id, created_at, is_used`
+		RETURNING id, created_at`
 
 	args := []any{car.Brand, car.Description, car.Color, car.Year, car.Price, car.OwnerID}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
-	return m.DB.QueryRowContext(ctx, query, args...).Scan(&car.ID, &car.CreatedAt, &car.IsUsed)
-}
-
-func (m CarModel) InsertToRent(car *Car, userID int64) error {
-	query := `
-		INSERT INTO rented_cars (user_id, car_id, price, taking_date)
-		VALUES ($1, $2, $3, $4)
-		RETURNING car_id`
-
-	args := []any{userID, car.ID, car.Price, time.Now()}
-
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
-	defer cancel()
-
-	return m.DB.QueryRowContext(ctx, query, args...).Scan(&car.ID)
-}
-
-func (m CarModel) DeleteFromRent(car *Car, userID int64) error {
-	query := `
-		DELETE FROM rented_cars
-		WHERE car_id = $1 
-		AND user_id = $2`
-
-	args := []any{
-		car.ID,
-		userID,
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
 	}
+	defer tx.Rollback()
 
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
-	defer cancel()
-
-	result, err := m.DB.ExecContext(ctx, query, args...)
+	err = tx.QueryRowContext(ctx, query, args...).Scan(&car.ID, &car.CreatedAt)
 	if err != nil {
 		return err
 	}
 
-	rowsAffected, err := result.RowsAffected()
+	// A new car has no rentals, so it can't be in use.
+	car.IsUsed = false
+
+	err = events.Enqueue(ctx, tx, events.TypeCarCreated, events.CarCreated{
+		CarID:       car.ID,
+		OwnerID:     car.OwnerID,
+		Brand:       car.Brand,
+		Description: car.Description,
+		Color:       car.Color,
+		Year:        car.Year,
+		Price:       car.Price,
+	})
 	if err != nil {
 		return err
 	}
-	if rowsAffected == 0 {
-		return ErrRecordNotFound
-	}
 
-	return nil
+	return tx.Commit()
 }
 
 func (m CarModel) Get(id int64) (*Car, error) {
@@ -89,7 +84,10 @@ func (m CarModel) Get(id int64) (*Car, error) {
 		return nil, ErrRecordNotFound
 	}
 
-	query := `SELECT * FROM car WHERE id = $1`
+	query := `
+		SELECT id, created_at, brand, description, color, year, price, ` + isUsedColumn + `, owner_id
+		FROM car
+		WHERE id = $1`
 
 	var car Car
 
@@ -120,12 +118,69 @@ func (m CarModel) Get(id int64) (*Car, error) {
 	return &car, nil
 }
 
-func (m CarModel) Update(car *Car) error {
+// Update applies update to the car and stores the result together with an
+// audit entry of the changed fields by userID. The car row is locked for the
+// duration of the transaction, so concurrent updates are applied one after the
+// other and each audit entry records the values it actually replaced. An error
+// from update is returned as is and nothing is stored.
+func (m CarModel) Update(id, userID int64, update func(car *Car) error) (*Car, error) {
+	if id < 1 {
+		return nil, ErrRecordNotFound
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
 	query := `
+		SELECT id, created_at, brand, description, color, year, price, ` + isUsedColumn + `, owner_id
+		FROM car
+		WHERE id = $1
+		FOR UPDATE`
+
+	var car Car
+
+	err = tx.QueryRowContext(ctx, query, id).Scan(
+		&car.ID,
+		&car.CreatedAt,
+		&car.Brand,
+		&car.Description,
+		&car.Color,
+		&car.Year,
+		&car.Price,
+		&car.IsUsed,
+		&car.OwnerID,
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrRecordNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	before := car
+
+	err = update(&car)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := DiffCar(&before, &car)
+	if len(changes) == 0 {
+		return &car, nil
+	}
+
+	query = `
 		UPDATE car
-		SET brand = $1, description = $2, color = $3, year = $4, price = $5, is_used = $6, owner_id = $7
-		WHERE id = $8
-		RETURNING id`
+		SET brand = $1, description = $2, color = $3, year = $4, price = $5
+		WHERE id = $6`
 
 	args := []any{
 		car.Brand,
@@ -133,15 +188,20 @@ func (m CarModel) Update(car *Car) error {
 		car.Color,
 		car.Year,
 		car.Price,
-		car.IsUsed,
-		car.OwnerID,
 		car.ID,
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
-	defer cancel()
+	_, err = tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	err = insertAudit(ctx, tx, &Audit{CarID: car.ID, UserID: userID, Changes: changes})
+	if err != nil {
+		return nil, err
+	}
 
-	return m.DB.QueryRowContext(ctx, query, args...).Scan(&car.ID)
+	return &car, tx.Commit()
 }
 
 func (m CarModel) Delete(id int64) error {
@@ -149,55 +209,139 @@ func (m CarModel) Delete(id int64) error {
 		return ErrRecordNotFound
 	}
 
-	query := `DELETE FROM car WHERE id = $1`
+	query := `DELETE FROM car WHERE id = $1 RETURNING owner_id`
 
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
-	result, err := m.DB.ExecContext(ctx, query, id)
+	tx, err := m.DB.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
+	defer tx.Rollback()
 
-	rowsAffected, err := result.RowsAffected()
+	var ownerID int64
+
+	err = tx.QueryRowContext(ctx, query, id).Scan(&ownerID)
 	if err != nil {
-		return err
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return ErrRecordNotFound
+		default:
+			return err
+		}
 	}
-	if rowsAffected == 0 {
-		return ErrRecordNotFound
+
+	err = events.Enqueue(ctx, tx, events.TypeCarDeleted, events.CarDeleted{CarID: id, OwnerID: ownerID})
+	if err != nil {
+		return err
 	}
 
-	return nil
+	return tx.Commit()
 }
 
-func (m CarModel) GetAll(brand string, color string, filters data.Filters) ([]*Car, data.Metadata, error) {
-	query := fmt.Sprintf(`
-		SELECT count(*) OVER(), * FROM car
-		WHERE (to_tsvector('simple', brand) @@ plainto_tsquery('simple', $1) OR $1 = '')
+// CarSearch narrows down the cars returned by GetAll. Zero values leave the
+// corresponding criterion out.
+type CarSearch struct {
+	Brand     string
+	Color     string
+	Query     string
+	MinPrice  int32
+	MaxPrice  int32
+	MinYear   int32
+	MaxYear   int32
+	OwnerID   int64
+	Available bool
+}
+
+// textColumn is what the q search parameter matches against.
+const textColumn = `to_tsvector('simple', brand || ' ' || description)`
+
+func (m CarModel) GetAll(search CarSearch, filters data.Filters) ([]*Car, data.Metadata, error) {
+	columns := fmt.Sprintf(`id, created_at, brand, description, color, year, price, %s, owner_id,
+			ts_rank(%s, plainto_tsquery('simple', $3)) AS rank`, isUsedColumn, textColumn)
+
+	where := fmt.Sprintf(`(to_tsvector('simple', brand) @@ plainto_tsquery('simple', $1) OR $1 = '')
 		AND (to_tsvector('simple', color) @@ plainto_tsquery('simple', $2) OR $2 = '')
+		AND (%s @@ plainto_tsquery('simple', $3) OR $3 = '')
+		AND (price >= $4 OR $4 = 0)
+		AND (price <= $5 OR $5 = 0)
+		AND (year >= $6 OR $6 = 0)
+		AND (year <= $7 OR $7 = 0)
+		AND (owner_id = $8 OR $8 = 0)
+		AND (NOT $9 OR NOT EXISTS (
+			SELECT 1 FROM rented_cars
+			WHERE rented_cars.car_id = car.id
+			AND rented_cars.status = 'active'
+			AND rented_cars.taking_date <= now()))`, textColumn)
+
+	args := []any{
+		search.Brand,
+		search.Color,
+		search.Query,
+		search.MinPrice,
+		search.MaxPrice,
+		search.MinYear,
+		search.MaxYear,
+		search.OwnerID,
+		search.Available,
+	}
+
+	var query string
+
+	if filters.Cursor == nil {
+		query = fmt.Sprintf(`
+		SELECT count(*) OVER(), %s
+		FROM car
+		WHERE %s
 		ORDER BY %s %s, id ASC
-		LIMIT $3 OFFSET $4`, filters.SortColumn(), filters.SortDirection())
+		LIMIT $10 OFFSET $11`, columns, where, filters.SortColumn(), filters.SortDirection())
+
+		args = append(args, filters.Limit(), filters.Offset())
+	} else {
+		// The computed columns can only be compared once they have a name,
+		// hence the subquery. One extra row tells whether there is a next page.
+		args = append(args, filters.Limit()+1)
+
+		after := "TRUE"
+		if !filters.Cursor.First() {
+			after = fmt.Sprintf("(%[1]s %[2]s $11 OR (%[1]s = $11 AND id > $12))", filters.SortColumn(), filters.CursorOperator())
+			args = append(args, filters.Cursor.Value, filters.Cursor.ID)
+		}
+
+		query = fmt.Sprintf(`
+		SELECT *
+		FROM (SELECT %s FROM car WHERE %s) AS car
+		WHERE %s
+		ORDER BY %s %s, id ASC
+		LIMIT $10`, columns, where, after, filters.SortColumn(), filters.SortDirection())
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
-	args := []any{brand, color, filters.Limit(), filters.Offset()}
-
 	rows, err := m.DB.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, data.Metadata{}, err
+		var pqErr *pq.Error
+		switch {
+		case errors.As(err, &pqErr) && (pqErr.Code == "22P02" || pqErr.Code == "22003"):
+			return nil, data.Metadata{}, data.ErrInvalidCursor
+		default:
+			return nil, data.Metadata{}, err
+		}
 	}
 
 	defer rows.Close()
 
 	totalRecords := 0
 	cars := []*Car{}
+	ranks := []float64{}
 
 	for rows.Next() {
 		var car Car
+		var rank float64
 
-		err := rows.Scan(
-			&totalRecords,
+		dest := []any{
 			&car.ID,
 			&car.CreatedAt,
 			&car.Brand,
@@ -207,23 +351,60 @@ func (m CarModel) GetAll(brand string, color string, filters data.Filters) ([]*C
 			&car.Price,
 			&car.IsUsed,
 			&car.OwnerID,
-		)
+			&rank,
+		}
+		if filters.Cursor == nil {
+			dest = append([]any{&totalRecords}, dest...)
+		}
+
+		err := rows.Scan(dest...)
 		if err != nil {
 			return nil, data.Metadata{}, err
 		}
 
 		cars = append(cars, &car)
+		ranks = append(ranks, rank)
 	}
 
 	if err = rows.Err(); err != nil {
 		return nil, data.Metadata{}, err
 	}
 
+	if filters.Cursor != nil {
+		next := ""
+		if len(cars) > filters.Limit() {
+			cars = cars[:filters.Limit()]
+			last := cars[len(cars)-1]
+			next = data.NewCursor(filters.Sort, carSortKey(last, ranks[len(cars)-1], filters.SortColumn()), last.ID)
+		}
+
+		return cars, data.CalculateCursorMetadata(filters.PageSize, next), nil
+	}
+
 	metadata := data.CalculateMetadata(totalRecords, filters.Page, filters.PageSize)
 
 	return cars, metadata, nil
 }
 
+// carSortKey returns the value of the sort column for car as it is stored in
+// a cursor.
+func carSortKey(car *Car, rank float64, column string) any {
+	switch column {
+	case "brand":
+		return car.Brand
+	case "year":
+		return car.Year
+	case "price":
+		return car.Price
+	case "is_used":
+		return car.IsUsed
+	case "rank":
+		return rank
+	default:
+		return car.ID
+	}
+}
+
 func ValidateCar(v *validator.Validator, car *Car) {
 	v.Check(car.Brand != "", "brand", "must be provided")
 	v.Check(len(car.Brand) <= 500, "brand", "must not be more than 500 bytes long")
@@ -238,3 +419,20 @@ func ValidateCar(v *validator.Validator, car *Car) {
 	v.Check(car.Price != 0, "price", "must be provided")
 	v.Check(car.Price > 0, "price", "must be a positive integer")
 }
+
+func ValidateCarSearch(v *validator.Validator, search CarSearch) {
+	v.Check(search.MinPrice >= 0, "min_price", "must not be negative")
+	v.Check(search.MaxPrice >= 0, "max_price", "must not be negative")
+	if search.MinPrice > 0 && search.MaxPrice > 0 {
+		v.Check(search.MinPrice <= search.MaxPrice, "max_price", "must not be less than min_price")
+	}
+
+	v.Check(search.MinYear == 0 || search.MinYear >= 1888, "min_year", "must be greater than 1888")
+	v.Check(search.MaxYear == 0 || search.MaxYear >= 1888, "max_year", "must be greater than 1888")
+	if search.MinYear > 0 && search.MaxYear > 0 {
+		v.Check(search.MinYear <= search.MaxYear, "max_year", "must not be less than min_year")
+	}
+
+	v.Check(search.OwnerID >= 0, "owner_id", "must be a positive integer")
+	v.Check(len(search.Query) <= 500, "q", "must not be more than 500 bytes long")
+}
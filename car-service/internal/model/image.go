@@ -0,0 +1,122 @@
+package model
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type ImageModel struct {
+	DB *sql.DB
+}
+
+// Image is a picture of a car. The blob keys are internal, clients see the
+// URLs filled in by the handlers. ThumbnailKey is empty until the thumbnail
+// has been generated.
+type Image struct {
+	ID           int64     `json:"id"`
+	CarID        int64     `json:"car_id"`
+	CreatedAt    time.Time `json:"created_at"`
+	ContentType  string    `json:"content_type"`
+	Key          string    `json:"-"`
+	ThumbnailKey string    `json:"-"`
+	URL          string    `json:"url"`
+	ThumbnailURL string    `json:"thumbnail_url,omitempty"`
+}
+
+func (m ImageModel) Insert(image *Image) error {
+	query := `
+		INSERT INTO car_images (car_id, content_type, key)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	err := m.DB.QueryRowContext(ctx, query, image.CarID, image.ContentType, image.Key).Scan(&image.ID, &image.CreatedAt)
+	if err != nil {
+		var pqErr *pq.Error
+		switch {
+		case errors.As(err, &pqErr) && pqErr.Code == "23503":
+			return ErrRecordNotFound
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m ImageModel) SetThumbnail(id int64, key string) error {
+	query := `UPDATE car_images SET thumbnail_key = $1 WHERE id = $2`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := m.DB.ExecContext(ctx, query, key, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// GetForCars returns the images of the given cars keyed by car id, oldest
+// first.
+func (m ImageModel) GetForCars(carIDs []int64) (map[int64][]*Image, error) {
+	images := make(map[int64][]*Image)
+	if len(carIDs) == 0 {
+		return images, nil
+	}
+
+	query := `
+		SELECT id, car_id, created_at, content_type, key, COALESCE(thumbnail_key, '')
+		FROM car_images
+		WHERE car_id = ANY($1)
+		ORDER BY id`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := m.DB.QueryContext(ctx, query, pq.Array(carIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var image Image
+
+		err := rows.Scan(
+			&image.ID,
+			&image.CarID,
+			&image.CreatedAt,
+			&image.ContentType,
+			&image.Key,
+			&image.ThumbnailKey,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		images[image.CarID] = append(images[image.CarID], &image)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return images, nil
+}
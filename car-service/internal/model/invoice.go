@@ -0,0 +1,98 @@
+package model
+
+import (
+	"car-service/internal/pricing"
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+type InvoiceModel struct {
+	DB *sql.DB
+}
+
+type Invoice struct {
+	ID         int64     `json:"id"`
+	RentalID   int64     `json:"rental_id"`
+	UserID     int64     `json:"user_id"`
+	CarID      int64     `json:"car_id"`
+	CreatedAt  time.Time `json:"created_at"`
+	DailyPrice int32     `json:"daily_price"`
+	TakingDate time.Time `json:"taking_date"`
+	ReturnDate time.Time `json:"return_date"`
+	ReturnedAt time.Time `json:"returned_at"`
+	pricing.Charge
+}
+
+func insertInvoice(ctx context.Context, tx *sql.Tx, invoice *Invoice) error {
+	query := `
+		INSERT INTO invoices (rental_id, user_id, car_id, daily_price, taking_date, return_date, returned_at,
+			days, hours, late_hours, base_amount, late_fee, total)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		RETURNING id, created_at`
+
+	args := []any{
+		invoice.RentalID,
+		invoice.UserID,
+		invoice.CarID,
+		invoice.DailyPrice,
+		invoice.TakingDate,
+		invoice.ReturnDate,
+		invoice.ReturnedAt,
+		invoice.Days,
+		invoice.Hours,
+		invoice.LateHours,
+		invoice.BaseAmount,
+		invoice.LateFee,
+		invoice.Total,
+	}
+
+	return tx.QueryRowContext(ctx, query, args...).Scan(&invoice.ID, &invoice.CreatedAt)
+}
+
+func (m InvoiceModel) GetForRental(rentalID int64) (*Invoice, error) {
+	if rentalID < 1 {
+		return nil, ErrRecordNotFound
+	}
+
+	query := `
+		SELECT id, rental_id, user_id, car_id, created_at, daily_price, taking_date, return_date, returned_at,
+			days, hours, late_hours, base_amount, late_fee, total
+		FROM invoices
+		WHERE rental_id = $1`
+
+	var invoice Invoice
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	err := m.DB.QueryRowContext(ctx, query, rentalID).Scan(
+		&invoice.ID,
+		&invoice.RentalID,
+		&invoice.UserID,
+		&invoice.CarID,
+		&invoice.CreatedAt,
+		&invoice.DailyPrice,
+		&invoice.TakingDate,
+		&invoice.ReturnDate,
+		&invoice.ReturnedAt,
+		&invoice.Days,
+		&invoice.Hours,
+		&invoice.LateHours,
+		&invoice.BaseAmount,
+		&invoice.LateFee,
+		&invoice.Total,
+	)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrRecordNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return &invoice, nil
+}
@@ -0,0 +1,662 @@
+package model
+
+import (
+	"car-service/internal/data"
+	"car-service/internal/pricing"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// memoryStore keeps cars, rentals, invoices, images and the audit trail in
+// process memory. It mirrors the semantics of the Postgres models, including
+// filtering, sorting and pagination, so handlers can be exercised without a
+// database.
+type memoryStore struct {
+	mu       sync.Mutex
+	cars     map[int64]Car
+	rentals  map[int64]Rental
+	invoices map[int64]Invoice
+	images   map[int64]Image
+	audits   map[int64]Audit
+	lastID   struct{ car, rental, invoice, image, audit int64 }
+}
+
+type memoryCarModel struct{ store *memoryStore }
+type memoryRentalModel struct{ store *memoryStore }
+type memoryInvoiceModel struct{ store *memoryStore }
+type memoryImageModel struct{ store *memoryStore }
+type memoryAuditModel struct{ store *memoryStore }
+
+func NewMemoryModels() Models {
+	store := &memoryStore{
+		cars:     make(map[int64]Car),
+		rentals:  make(map[int64]Rental),
+		invoices: make(map[int64]Invoice),
+		images:   make(map[int64]Image),
+		audits:   make(map[int64]Audit),
+	}
+
+	return Models{
+		Car:     memoryCarModel{store},
+		Rental:  memoryRentalModel{store},
+		Invoice: memoryInvoiceModel{store},
+		Image:   memoryImageModel{store},
+		Audit:   memoryAuditModel{store},
+	}
+}
+
+// isUsed must be called with the store locked.
+func (s *memoryStore) isUsed(carID int64, now time.Time) bool {
+	for _, rental := range s.rentals {
+		if rental.CarID == carID && rental.Status == RentalStatusActive && !rental.TakingDate.After(now) {
+			return true
+		}
+	}
+	return false
+}
+
+// booked returns the active rentals of the car overlapping [from, to), ordered
+// by taking_date. It must be called with the store locked.
+func (s *memoryStore) booked(carID int64, from, to, now time.Time) []Rental {
+	var rentals []Rental
+
+	for _, rental := range s.rentals {
+		if rental.CarID != carID || rental.Status != RentalStatusActive {
+			continue
+		}
+		if rental.TakingDate.Before(to) && rentalEnd(rental, now).After(from) {
+			rentals = append(rentals, rental)
+		}
+	}
+
+	sort.Slice(rentals, func(i, j int) bool {
+		return rentals[i].TakingDate.Before(rentals[j].TakingDate)
+	})
+
+	return rentals
+}
+
+func rentalEnd(rental Rental, now time.Time) time.Time {
+	if rental.ReturnDate.Before(now) {
+		return now
+	}
+	return rental.ReturnDate
+}
+
+func (m memoryCarModel) Insert(car *Car) error {
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	m.store.lastID.car++
+	car.ID = m.store.lastID.car
+	car.CreatedAt = time.Now().Truncate(time.Second)
+	car.IsUsed = false
+
+	m.store.cars[car.ID] = *car
+
+	return nil
+}
+
+func (m memoryCarModel) Get(id int64) (*Car, error) {
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	car, ok := m.store.cars[id]
+	if !ok {
+		return nil, ErrRecordNotFound
+	}
+
+	car.IsUsed = m.store.isUsed(id, time.Now())
+
+	return &car, nil
+}
+
+func (m memoryCarModel) Update(id, userID int64, update func(car *Car) error) (*Car, error) {
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	before, ok := m.store.cars[id]
+	if !ok {
+		return nil, ErrRecordNotFound
+	}
+	before.IsUsed = m.store.isUsed(id, time.Now())
+
+	car := before
+
+	err := update(&car)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := DiffCar(&before, &car)
+	if len(changes) == 0 {
+		return &car, nil
+	}
+
+	stored := before
+	stored.Brand = car.Brand
+	stored.Description = car.Description
+	stored.Color = car.Color
+	stored.Year = car.Year
+	stored.Price = car.Price
+	m.store.cars[id] = stored
+
+	m.store.lastID.audit++
+	m.store.audits[m.store.lastID.audit] = Audit{
+		ID:        m.store.lastID.audit,
+		CarID:     id,
+		UserID:    userID,
+		CreatedAt: time.Now().Truncate(time.Second),
+		Changes:   changes,
+	}
+
+	return &car, nil
+}
+
+func (m memoryCarModel) Delete(id int64) error {
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	if _, ok := m.store.cars[id]; !ok {
+		return ErrRecordNotFound
+	}
+
+	delete(m.store.cars, id)
+
+	for imageID, image := range m.store.images {
+		if image.CarID == id {
+			delete(m.store.images, imageID)
+		}
+	}
+
+	for auditID, audit := range m.store.audits {
+		if audit.CarID == id {
+			delete(m.store.audits, auditID)
+		}
+	}
+
+	return nil
+}
+
+func (m memoryCarModel) GetAll(search CarSearch, filters data.Filters) ([]*Car, data.Metadata, error) {
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	now := time.Now()
+	cars := []*Car{}
+	ranks := make(map[int64]int)
+
+	for _, car := range m.store.cars {
+		text := car.Brand + " " + car.Description
+
+		switch {
+		case !matchesText(car.Brand, search.Brand), !matchesText(car.Color, search.Color), !matchesText(text, search.Query):
+			continue
+		case search.MinPrice > 0 && car.Price < search.MinPrice, search.MaxPrice > 0 && car.Price > search.MaxPrice:
+			continue
+		case search.MinYear > 0 && car.Year < search.MinYear, search.MaxYear > 0 && car.Year > search.MaxYear:
+			continue
+		case search.OwnerID > 0 && car.OwnerID != search.OwnerID:
+			continue
+		}
+
+		car := car
+		car.IsUsed = m.store.isUsed(car.ID, now)
+		if search.Available && car.IsUsed {
+			continue
+		}
+
+		ranks[car.ID] = rankText(text, search.Query)
+		cars = append(cars, &car)
+	}
+
+	sortRecords(cars, filters, func(a, b *Car) int {
+		switch filters.SortColumn() {
+		case "brand":
+			return strings.Compare(a.Brand, b.Brand)
+		case "year":
+			return compareInts(a.Year, b.Year)
+		case "price":
+			return compareInts(a.Price, b.Price)
+		case "is_used":
+			return compareBools(a.IsUsed, b.IsUsed)
+		case "rank":
+			return compareInts(int64(ranks[a.ID]), int64(ranks[b.ID]))
+		default:
+			return compareInts(a.ID, b.ID)
+		}
+	}, func(car *Car) int64 { return car.ID })
+
+	if filters.Cursor != nil {
+		return paginateCursor(cars, filters, func(car *Car) any {
+			return carSortKey(car, float64(ranks[car.ID]), filters.SortColumn())
+		}, func(car *Car) int64 { return car.ID })
+	}
+
+	cars, metadata := paginate(cars, filters)
+
+	return cars, metadata, nil
+}
+
+func (m memoryRentalModel) Rent(rental *Rental) error {
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	car, ok := m.store.cars[rental.CarID]
+	if !ok {
+		return ErrRecordNotFound
+	}
+
+	if len(m.store.booked(rental.CarID, rental.TakingDate, rental.ReturnDate, time.Now())) > 0 {
+		return ErrCarOccupied
+	}
+
+	m.store.lastID.rental++
+	rental.ID = m.store.lastID.rental
+	rental.Price = car.Price
+	rental.Status = RentalStatusActive
+
+	m.store.rentals[rental.ID] = *rental
+
+	return nil
+}
+
+func (m memoryRentalModel) Return(carID, userID int64, policy pricing.Policy) (*Rental, *Invoice, error) {
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	if _, ok := m.store.cars[carID]; !ok {
+		return nil, nil, ErrRecordNotFound
+	}
+
+	returnedAt := time.Now()
+
+	var (
+		rental Rental
+		found  bool
+	)
+
+	for _, r := range m.store.rentals {
+		if r.CarID == carID && r.UserID == userID && r.Status == RentalStatusActive && !r.TakingDate.After(returnedAt) {
+			rental, found = r, true
+			break
+		}
+	}
+
+	if !found {
+		return nil, nil, ErrCarNotUsed
+	}
+
+	m.store.lastID.invoice++
+
+	invoice := &Invoice{
+		ID:         m.store.lastID.invoice,
+		RentalID:   rental.ID,
+		UserID:     rental.UserID,
+		CarID:      rental.CarID,
+		CreatedAt:  returnedAt.Truncate(time.Second),
+		DailyPrice: rental.Price,
+		TakingDate: rental.TakingDate,
+		ReturnDate: rental.ReturnDate,
+		ReturnedAt: returnedAt,
+		Charge:     policy.Calculate(rental.Price, rental.TakingDate, rental.ReturnDate, returnedAt),
+	}
+
+	rental.Status = RentalStatusReturned
+	rental.ReturnedAt = &invoice.ReturnedAt
+	rental.FinalPrice = &invoice.Total
+
+	m.store.rentals[rental.ID] = rental
+	m.store.invoices[invoice.ID] = *invoice
+
+	return &rental, invoice, nil
+}
+
+func (m memoryRentalModel) GetAvailability(carID int64, from, to time.Time) (*Availability, error) {
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	now := time.Now()
+
+	availability := &Availability{
+		CarID:  carID,
+		From:   from,
+		To:     to,
+		Booked: []Period{},
+	}
+
+	for _, rental := range m.store.booked(carID, from, to, now) {
+		availability.Booked = append(availability.Booked, Period{
+			TakingDate: rental.TakingDate,
+			ReturnDate: rentalEnd(rental, now),
+		})
+	}
+
+	availability.Available = len(availability.Booked) == 0
+
+	return availability, nil
+}
+
+func (m memoryRentalModel) GetAll(carID, userID int64, status string, filters data.Filters) ([]*Rental, data.Metadata, error) {
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	rentals := []*Rental{}
+
+	for _, rental := range m.store.rentals {
+		if (carID != 0 && rental.CarID != carID) || (userID != 0 && rental.UserID != userID) || (status != "" && rental.Status != status) {
+			continue
+		}
+
+		rental := rental
+		rentals = append(rentals, &rental)
+	}
+
+	sortRecords(rentals, filters, func(a, b *Rental) int {
+		switch filters.SortColumn() {
+		case "taking_date":
+			return compareTimes(a.TakingDate, b.TakingDate)
+		case "return_date":
+			return compareTimes(a.ReturnDate, b.ReturnDate)
+		case "price":
+			return compareInts(a.Price, b.Price)
+		case "status":
+			return strings.Compare(a.Status, b.Status)
+		default:
+			return compareInts(a.ID, b.ID)
+		}
+	}, func(rental *Rental) int64 { return rental.ID })
+
+	rentals, metadata := paginate(rentals, filters)
+
+	return rentals, metadata, nil
+}
+
+func (m memoryInvoiceModel) GetForRental(rentalID int64) (*Invoice, error) {
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	for _, invoice := range m.store.invoices {
+		if invoice.RentalID == rentalID {
+			return &invoice, nil
+		}
+	}
+
+	return nil, ErrRecordNotFound
+}
+
+func (m memoryImageModel) Insert(image *Image) error {
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	if _, ok := m.store.cars[image.CarID]; !ok {
+		return ErrRecordNotFound
+	}
+
+	m.store.lastID.image++
+	image.ID = m.store.lastID.image
+	image.CreatedAt = time.Now().Truncate(time.Second)
+
+	m.store.images[image.ID] = *image
+
+	return nil
+}
+
+func (m memoryImageModel) SetThumbnail(id int64, key string) error {
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	image, ok := m.store.images[id]
+	if !ok {
+		return ErrRecordNotFound
+	}
+
+	image.ThumbnailKey = key
+	m.store.images[id] = image
+
+	return nil
+}
+
+func (m memoryImageModel) GetForCars(carIDs []int64) (map[int64][]*Image, error) {
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	images := make(map[int64][]*Image)
+
+	for _, carID := range carIDs {
+		for _, image := range m.store.images {
+			if image.CarID == carID {
+				image := image
+				images[carID] = append(images[carID], &image)
+			}
+		}
+
+		sort.Slice(images[carID], func(i, j int) bool {
+			return images[carID][i].ID < images[carID][j].ID
+		})
+	}
+
+	return images, nil
+}
+
+func (m memoryAuditModel) GetForCar(carID int64) ([]*Audit, error) {
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	audits := []*Audit{}
+
+	for _, audit := range m.store.audits {
+		if audit.CarID == carID {
+			audit := audit
+			audits = append(audits, &audit)
+		}
+	}
+
+	sort.Slice(audits, func(i, j int) bool {
+		return audits[i].ID > audits[j].ID
+	})
+
+	return audits, nil
+}
+
+// matchesText approximates to_tsvector('simple', field) @@
+// plainto_tsquery('simple', query): every word of the query has to appear as
+// a word of the field, ignoring case. An empty query matches everything.
+func matchesText(field, query string) bool {
+	words := make(map[string]bool)
+	for _, word := range splitWords(field) {
+		words[word] = true
+	}
+
+	for _, word := range splitWords(query) {
+		if !words[word] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// rankText approximates ts_rank by counting how often the query words occur
+// in field.
+func rankText(field, query string) int {
+	words := make(map[string]bool)
+	for _, word := range splitWords(query) {
+		words[word] = true
+	}
+
+	rank := 0
+	for _, word := range splitWords(field) {
+		if words[word] {
+			rank++
+		}
+	}
+
+	return rank
+}
+
+func splitWords(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// sortRecords orders records by the filters' sort column and direction, with
+// id ascending as the tie-breaker, matching ORDER BY <column> <dir>, id ASC.
+func sortRecords[T any](records []T, filters data.Filters, compare func(a, b T) int, id func(T) int64) {
+	descending := filters.SortDirection() == "DESC"
+
+	sort.SliceStable(records, func(i, j int) bool {
+		c := compare(records[i], records[j])
+		if descending {
+			c = -c
+		}
+		if c != 0 {
+			return c < 0
+		}
+		return id(records[i]) < id(records[j])
+	})
+}
+
+func paginate[T any](records []T, filters data.Filters) ([]T, data.Metadata) {
+	total := len(records)
+
+	start := filters.Offset()
+	if start > total {
+		start = total
+	}
+
+	end := start + filters.Limit()
+	if end > total {
+		end = total
+	}
+
+	page := records[start:end]
+
+	// count(*) OVER() yields no rows, and so no total, for a page past the end.
+	if len(page) == 0 {
+		return page, data.Metadata{}
+	}
+
+	return page, data.CalculateMetadata(total, filters.Page, filters.PageSize)
+}
+
+// paginateCursor returns the page of already sorted records following the
+// filters' cursor, matching the keyset condition of the Postgres models.
+func paginateCursor[T any](records []T, filters data.Filters, key func(T) any, id func(T) int64) ([]T, data.Metadata, error) {
+	start := 0
+
+	if !filters.Cursor.First() {
+		descending := filters.SortDirection() == "DESC"
+		start = len(records)
+
+		for i, record := range records {
+			c, err := compareCursor(key(record), filters.Cursor.Value)
+			if err != nil {
+				return nil, data.Metadata{}, err
+			}
+			if descending {
+				c = -c
+			}
+			if c > 0 || c == 0 && id(record) > filters.Cursor.ID {
+				start = i
+				break
+			}
+		}
+	}
+
+	page := records[start:]
+
+	next := ""
+	if len(page) > filters.Limit() {
+		page = page[:filters.Limit()]
+		last := page[len(page)-1]
+		next = data.NewCursor(filters.Sort, key(last), id(last))
+	}
+
+	return page, data.CalculateCursorMetadata(filters.PageSize, next), nil
+}
+
+// compareCursor compares a sort key with the value stored in a cursor, which
+// is parsed as the type of the key.
+func compareCursor(key any, value string) (int, error) {
+	switch k := key.(type) {
+	case string:
+		return strings.Compare(k, value), nil
+	case bool:
+		v, err := strconv.ParseBool(value)
+		if err != nil {
+			return 0, data.ErrInvalidCursor
+		}
+		return compareBools(k, v), nil
+	case float64:
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return 0, data.ErrInvalidCursor
+		}
+		return compareFloats(k, v), nil
+	case int32:
+		v, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return 0, data.ErrInvalidCursor
+		}
+		return compareInts(k, int32(v)), nil
+	case int64:
+		v, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return 0, data.ErrInvalidCursor
+		}
+		return compareInts(k, v), nil
+	default:
+		return 0, fmt.Errorf("unsupported sort key type %T", key)
+	}
+}
+
+func compareFloats(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareInts[T int32 | int64](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareBools(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case !a:
+		return -1
+	default:
+		return 1
+	}
+}
+
+func compareTimes(a, b time.Time) int {
+	switch {
+	case a.Before(b):
+		return -1
+	case a.After(b):
+		return 1
+	default:
+		return 0
+	}
+}
@@ -7,14 +7,31 @@ import (
 
 var (
 	ErrRecordNotFound = errors.New("record not found")
+	ErrCarOccupied    = errors.New("car occupied")
+	ErrCarNotUsed     = errors.New("car not used")
 )
 
+// PermissionCarsModerate is the permission code, granted by the user service,
+// that allows changing and deleting cars of other owners.
+const PermissionCarsModerate = "cars:moderate"
+
+// PermissionRentalsRead allows reading the rentals and invoices of other users.
+const PermissionRentalsRead = "rentals:read"
+
 type Models struct {
-	Car CarModel
+	Car     CarRepository
+	Rental  RentalRepository
+	Invoice InvoiceRepository
+	Image   ImageRepository
+	Audit   AuditRepository
 }
 
 func NewModels(db *sql.DB) Models {
 	return Models{
-		Car: CarModel{DB: db},
+		Car:     CarModel{DB: db},
+		Rental:  RentalModel{DB: db},
+		Invoice: InvoiceModel{DB: db},
+		Image:   ImageModel{DB: db},
+		Audit:   AuditModel{DB: db},
 	}
 }
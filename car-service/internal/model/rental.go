@@ -0,0 +1,338 @@
+package model
+
+import (
+	"car-service/internal/data"
+	"car-service/internal/events"
+	"car-service/internal/pricing"
+	"car-service/internal/validator"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	RentalStatusActive   = "active"
+	RentalStatusReturned = "returned"
+)
+
+type RentalModel struct {
+	DB *sql.DB
+}
+
+type Rental struct {
+	ID         int64      `json:"id"`
+	UserID     int64      `json:"user_id"`
+	CarID      int64      `json:"car_id"`
+	Price      int32      `json:"price"`
+	TakingDate time.Time  `json:"taking_date"`
+	ReturnDate time.Time  `json:"return_date"`
+	Status     string     `json:"status"`
+	ReturnedAt *time.Time `json:"returned_at,omitempty"`
+	FinalPrice *int64     `json:"final_price,omitempty"`
+}
+
+type Period struct {
+	TakingDate time.Time `json:"taking_date"`
+	ReturnDate time.Time `json:"return_date"`
+}
+
+type Availability struct {
+	CarID     int64     `json:"car_id"`
+	From      time.Time `json:"from"`
+	To        time.Time `json:"to"`
+	Available bool      `json:"available"`
+	Booked    []Period  `json:"booked"`
+}
+
+// An active rental blocks its car from taking_date until it is returned, so an
+// overdue rental keeps blocking the car past its return_date.
+const rentalEndColumn = `GREATEST(return_date, now())`
+
+// Rent books the car for the rental period. The car row is locked for the
+// duration of the transaction, so concurrent bookings of the same car are
+// serialized and the overlap check cannot be raced.
+func (m RentalModel) Rent(rental *Rental) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, `SELECT price FROM car WHERE id = $1 FOR UPDATE`, rental.CarID).Scan(&rental.Price)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return ErrRecordNotFound
+		default:
+			return err
+		}
+	}
+
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM rented_cars
+			WHERE car_id = $1
+			AND status = 'active'
+			AND taking_date < $3
+			AND ` + rentalEndColumn + ` > $2)`
+
+	var occupied bool
+
+	err = tx.QueryRowContext(ctx, query, rental.CarID, rental.TakingDate, rental.ReturnDate).Scan(&occupied)
+	if err != nil {
+		return err
+	}
+
+	if occupied {
+		return ErrCarOccupied
+	}
+
+	rental.Status = RentalStatusActive
+
+	query = `
+		INSERT INTO rented_cars (user_id, car_id, price, taking_date, return_date, status)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id`
+
+	args := []any{rental.UserID, rental.CarID, rental.Price, rental.TakingDate, rental.ReturnDate, rental.Status}
+
+	err = tx.QueryRowContext(ctx, query, args...).Scan(&rental.ID)
+	if err != nil {
+		return err
+	}
+
+	err = events.Enqueue(ctx, tx, events.TypeCarRented, events.CarRented{
+		CarID:      rental.CarID,
+		UserID:     rental.UserID,
+		Price:      rental.Price,
+		TakingDate: rental.TakingDate,
+		ReturnDate: rental.ReturnDate,
+	})
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Return closes the rental of the car that the user is currently driving and
+// bills it according to the pricing policy. The invoice is stored in the same
+// transaction.
+func (m RentalModel) Return(carID, userID int64, policy pricing.Policy) (*Rental, *Invoice, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback()
+
+	var id int64
+
+	err = tx.QueryRowContext(ctx, `SELECT id FROM car WHERE id = $1 FOR UPDATE`, carID).Scan(&id)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, nil, ErrRecordNotFound
+		default:
+			return nil, nil, err
+		}
+	}
+
+	returnedAt := time.Now()
+
+	query := `
+		SELECT id, user_id, car_id, price, taking_date, return_date
+		FROM rented_cars
+		WHERE car_id = $1
+		AND user_id = $2
+		AND status = 'active'
+		AND taking_date <= $3
+		FOR UPDATE`
+
+	var rental Rental
+
+	err = tx.QueryRowContext(ctx, query, carID, userID, returnedAt).Scan(
+		&rental.ID,
+		&rental.UserID,
+		&rental.CarID,
+		&rental.Price,
+		&rental.TakingDate,
+		&rental.ReturnDate,
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, nil, ErrCarNotUsed
+		default:
+			return nil, nil, err
+		}
+	}
+
+	invoice := &Invoice{
+		RentalID:   rental.ID,
+		UserID:     rental.UserID,
+		CarID:      rental.CarID,
+		DailyPrice: rental.Price,
+		TakingDate: rental.TakingDate,
+		ReturnDate: rental.ReturnDate,
+		ReturnedAt: returnedAt,
+		Charge:     policy.Calculate(rental.Price, rental.TakingDate, rental.ReturnDate, returnedAt),
+	}
+
+	rental.Status = RentalStatusReturned
+	rental.ReturnedAt = &invoice.ReturnedAt
+	rental.FinalPrice = &invoice.Total
+
+	query = `
+		UPDATE rented_cars
+		SET status = $1, returned_at = $2, final_price = $3
+		WHERE id = $4`
+
+	_, err = tx.ExecContext(ctx, query, rental.Status, rental.ReturnedAt, rental.FinalPrice, rental.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = insertInvoice(ctx, tx, invoice)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = events.Enqueue(ctx, tx, events.TypeCarReturned, events.CarReturned{
+		CarID:      rental.CarID,
+		UserID:     rental.UserID,
+		ReturnedAt: returnedAt,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &rental, invoice, nil
+}
+
+func (m RentalModel) GetAvailability(carID int64, from, to time.Time) (*Availability, error) {
+	query := `
+		SELECT taking_date, ` + rentalEndColumn + `
+		FROM rented_cars
+		WHERE car_id = $1
+		AND status = 'active'
+		AND taking_date < $3
+		AND ` + rentalEndColumn + ` > $2
+		ORDER BY taking_date`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := m.DB.QueryContext(ctx, query, carID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	availability := &Availability{
+		CarID:  carID,
+		From:   from,
+		To:     to,
+		Booked: []Period{},
+	}
+
+	for rows.Next() {
+		var period Period
+
+		err := rows.Scan(&period.TakingDate, &period.ReturnDate)
+		if err != nil {
+			return nil, err
+		}
+
+		availability.Booked = append(availability.Booked, period)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	availability.Available = len(availability.Booked) == 0
+
+	return availability, nil
+}
+
+// GetAll lists rentals of a car, of a user, or both. A zero carID or userID
+// does not filter on that column, an empty status lists every rental.
+func (m RentalModel) GetAll(carID, userID int64, status string, filters data.Filters) ([]*Rental, data.Metadata, error) {
+	query := fmt.Sprintf(`
+		SELECT count(*) OVER(), id, user_id, car_id, price, taking_date, return_date, status,
+			returned_at, final_price
+		FROM rented_cars
+		WHERE (car_id = $1 OR $1 = 0)
+		AND (user_id = $2 OR $2 = 0)
+		AND (status = $3 OR $3 = '')
+		ORDER BY %s %s, id ASC
+		LIMIT $4 OFFSET $5`, filters.SortColumn(), filters.SortDirection())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	args := []any{carID, userID, status, filters.Limit(), filters.Offset()}
+
+	rows, err := m.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, data.Metadata{}, err
+	}
+
+	defer rows.Close()
+
+	totalRecords := 0
+	rentals := []*Rental{}
+
+	for rows.Next() {
+		var rental Rental
+
+		err := rows.Scan(
+			&totalRecords,
+			&rental.ID,
+			&rental.UserID,
+			&rental.CarID,
+			&rental.Price,
+			&rental.TakingDate,
+			&rental.ReturnDate,
+			&rental.Status,
+			&rental.ReturnedAt,
+			&rental.FinalPrice,
+		)
+		if err != nil {
+			return nil, data.Metadata{}, err
+		}
+
+		rentals = append(rentals, &rental)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, data.Metadata{}, err
+	}
+
+	metadata := data.CalculateMetadata(totalRecords, filters.Page, filters.PageSize)
+
+	return rentals, metadata, nil
+}
+
+func ValidateRental(v *validator.Validator, rental *Rental) {
+	v.Check(rental.UserID > 0, "user_id", "must be provided")
+
+	v.Check(!rental.ReturnDate.IsZero(), "return_date", "must be provided")
+	v.Check(rental.ReturnDate.After(rental.TakingDate), "return_date", "must be after taking_date")
+
+	v.Check(rental.TakingDate.After(time.Now().Add(-time.Minute)), "taking_date", "must not be in the past")
+	v.Check(rental.TakingDate.Before(time.Now().AddDate(1, 0, 0)), "taking_date", "must not be more than a year ahead")
+}
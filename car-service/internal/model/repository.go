@@ -0,0 +1,39 @@
+package model
+
+import (
+	"car-service/internal/data"
+	"car-service/internal/pricing"
+	"time"
+)
+
+// The repositories are implemented by the Postgres models in this package and
+// by the in-memory store returned from NewMemoryModels.
+
+type CarRepository interface {
+	Insert(car *Car) error
+	Get(id int64) (*Car, error)
+	Update(id, userID int64, update func(car *Car) error) (*Car, error)
+	Delete(id int64) error
+	GetAll(search CarSearch, filters data.Filters) ([]*Car, data.Metadata, error)
+}
+
+type RentalRepository interface {
+	Rent(rental *Rental) error
+	Return(carID, userID int64, policy pricing.Policy) (*Rental, *Invoice, error)
+	GetAvailability(carID int64, from, to time.Time) (*Availability, error)
+	GetAll(carID, userID int64, status string, filters data.Filters) ([]*Rental, data.Metadata, error)
+}
+
+type InvoiceRepository interface {
+	GetForRental(rentalID int64) (*Invoice, error)
+}
+
+type ImageRepository interface {
+	Insert(image *Image) error
+	SetThumbnail(id int64, key string) error
+	GetForCars(carIDs []int64) (map[int64][]*Image, error)
+}
+
+type AuditRepository interface {
+	GetForCar(carID int64) ([]*Audit, error)
+}
@@ -0,0 +1,67 @@
+package pricing
+
+import (
+	"time"
+)
+
+// Policy describes how a rental is charged. Car prices are daily rates; a
+// partially used day is charged per started hour, but never more than a full
+// day.
+type Policy struct {
+	HourlyRate    int // percent of the daily price charged per started hour
+	MinimumHours  int // hours charged at least, however short the rental
+	LateSurcharge int // percent added to the hourly rate for hours past the return date
+}
+
+type Charge struct {
+	Days       int64 `json:"days"`
+	Hours      int64 `json:"hours"`
+	LateHours  int64 `json:"late_hours"`
+	BaseAmount int64 `json:"base_amount"`
+	LateFee    int64 `json:"late_fee"`
+	Total      int64 `json:"total"`
+}
+
+// Calculate charges a rental taken at takingDate, due at returnDate and
+// actually returned at returnedAt. The time up to returnDate is billed at the
+// regular rate, anything after it as late hours.
+func (p Policy) Calculate(dailyPrice int32, takingDate, returnDate, returnedAt time.Time) Charge {
+	daily := int64(dailyPrice)
+	hourly := divCeil(daily*int64(p.HourlyRate), 100)
+
+	end := returnedAt
+	if end.After(returnDate) {
+		end = returnDate
+	}
+
+	hours := hoursBetween(takingDate, end)
+	if hours < int64(p.MinimumHours) {
+		hours = int64(p.MinimumHours)
+	}
+
+	var charge Charge
+
+	charge.Days = hours / 24
+	charge.Hours = hours % 24
+
+	charge.BaseAmount = charge.Days*daily + min(charge.Hours*hourly, daily)
+
+	charge.LateHours = hoursBetween(returnDate, returnedAt)
+	charge.LateFee = divCeil(charge.LateHours*hourly*int64(100+p.LateSurcharge), 100)
+
+	charge.Total = charge.BaseAmount + charge.LateFee
+
+	return charge
+}
+
+func hoursBetween(from, to time.Time) int64 {
+	if !to.After(from) {
+		return 0
+	}
+
+	return divCeil(int64(to.Sub(from)), int64(time.Hour))
+}
+
+func divCeil(a, b int64) int64 {
+	return (a + b - 1) / b
+}
@@ -0,0 +1,53 @@
+package pricing
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCalculate(t *testing.T) {
+	policy := Policy{HourlyRate: 10, MinimumHours: 2, LateSurcharge: 50}
+	taking := time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	testTable := []struct {
+		name       string
+		returnDate time.Time
+		returnedAt time.Time
+		expected   Charge
+	}{
+		{
+			name:       "Minimum charge",
+			returnDate: taking.Add(24 * time.Hour),
+			returnedAt: taking.Add(20 * time.Minute),
+			expected:   Charge{Hours: 2, BaseAmount: 200, Total: 200},
+		},
+		{
+			name:       "Started hours",
+			returnDate: taking.Add(24 * time.Hour),
+			returnedAt: taking.Add(3*time.Hour + time.Minute),
+			expected:   Charge{Hours: 4, BaseAmount: 400, Total: 400},
+		},
+		{
+			name:       "Partial day capped at daily price",
+			returnDate: taking.Add(48 * time.Hour),
+			returnedAt: taking.Add(36 * time.Hour),
+			expected:   Charge{Days: 1, Hours: 12, BaseAmount: 2000, Total: 2000},
+		},
+		{
+			name:       "Late return",
+			returnDate: taking.Add(24 * time.Hour),
+			returnedAt: taking.Add(26*time.Hour + time.Minute),
+			expected:   Charge{Days: 1, LateHours: 3, BaseAmount: 1000, LateFee: 450, Total: 1450},
+		},
+	}
+
+	for _, testTable := range testTable {
+		t.Run(testTable.name, func(t *testing.T) {
+			charge := policy.Calculate(1000, taking, testTable.returnDate, testTable.returnedAt)
+
+			if charge != testTable.expected {
+				t.Errorf("wrong charge: got %+v want %+v", charge, testTable.expected)
+			}
+		})
+	}
+}
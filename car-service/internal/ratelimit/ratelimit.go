@@ -0,0 +1,115 @@
+// Package ratelimit implements token bucket rate limiting keyed by client.
+package ratelimit
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Limit allows bursts of up to Burst requests, refilled at RPS requests per
+// second. A limit with no rate is unlimited.
+type Limit struct {
+	RPS   float64
+	Burst int
+}
+
+func (l Limit) Unlimited() bool {
+	return l.RPS <= 0
+}
+
+// Result describes the state of a bucket after a call to Allow.
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	Reset      time.Duration
+	RetryAfter time.Duration
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+	full   time.Time
+}
+
+// sweepInterval is how often buckets that have refilled completely, and so are
+// indistinguishable from new ones, are dropped.
+const sweepInterval = time.Minute
+
+type Limiter struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func New() *Limiter {
+	return &Limiter{
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+// Allow takes a token from the bucket for key, creating a full one if needed.
+func (l *Limiter) Allow(key string, limit Limit) Result {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if now.Sub(l.lastSweep) > sweepInterval {
+		for key, b := range l.buckets {
+			if now.After(b.full) {
+				delete(l.buckets, key)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	burst := float64(limit.Burst)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: burst, last: now}
+		l.buckets[key] = b
+	}
+
+	b.tokens = math.Min(burst, b.tokens+now.Sub(b.last).Seconds()*limit.RPS)
+	b.last = now
+
+	result := Result{Limit: limit.Burst}
+	if b.tokens >= 1 {
+		b.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = seconds((1 - b.tokens) / limit.RPS)
+	}
+
+	result.Remaining = int(b.tokens)
+	result.Reset = seconds((burst - b.tokens) / limit.RPS)
+	b.full = now.Add(result.Reset)
+
+	return result
+}
+
+// SetHeaders writes the RateLimit-* headers, and Retry-After for rejected
+// requests.
+func SetHeaders(h http.Header, result Result) {
+	h.Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+	h.Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	h.Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.Reset)))
+
+	if !result.Allowed {
+		h.Set("Retry-After", strconv.Itoa(ceilSeconds(result.RetryAfter)))
+	}
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
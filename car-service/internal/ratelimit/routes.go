@@ -0,0 +1,119 @@
+package ratelimit
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Route overrides the default limit for requests matching Method and Pattern,
+// which uses httprouter syntax (:name matches one segment, *name the rest).
+type Route struct {
+	Method  string
+	Pattern string
+	Limit   Limit
+}
+
+func (r Route) Key() string {
+	return r.Method + " " + r.Pattern
+}
+
+type Routes []Route
+
+// ParseRoutes parses a comma separated list of route limits such as
+//
+//	POST /tokens/authentication=0.2:5,POST /tokens/introspect=off
+//
+// where 0.2:5 is the rate per second and the burst, and off disables limiting.
+func ParseRoutes(s string) (Routes, error) {
+	var routes Routes
+
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		route, value, ok := strings.Cut(item, "=")
+		if !ok {
+			return nil, fmt.Errorf("ratelimit: route %q has no limit", item)
+		}
+
+		method, pattern, ok := strings.Cut(strings.TrimSpace(route), " ")
+		if !ok || !strings.HasPrefix(pattern, "/") {
+			return nil, fmt.Errorf("ratelimit: route %q must be \"METHOD /path\"", route)
+		}
+
+		limit, err := ParseLimit(value)
+		if err != nil {
+			return nil, err
+		}
+
+		routes = append(routes, Route{
+			Method:  strings.ToUpper(method),
+			Pattern: strings.TrimSpace(pattern),
+			Limit:   limit,
+		})
+	}
+
+	return routes, nil
+}
+
+// ParseLimit parses a "rps:burst" pair or "off".
+func ParseLimit(s string) (Limit, error) {
+	s = strings.TrimSpace(s)
+	if s == "off" {
+		return Limit{}, nil
+	}
+
+	rps, burst, ok := strings.Cut(s, ":")
+	if !ok {
+		return Limit{}, fmt.Errorf("ratelimit: limit %q must be \"rps:burst\" or \"off\"", s)
+	}
+
+	var (
+		limit Limit
+		err   error
+	)
+
+	limit.RPS, err = strconv.ParseFloat(rps, 64)
+	if err != nil || limit.RPS <= 0 {
+		return Limit{}, fmt.Errorf("ratelimit: invalid rate in %q", s)
+	}
+
+	limit.Burst, err = strconv.Atoi(burst)
+	if err != nil || limit.Burst < 1 {
+		return Limit{}, fmt.Errorf("ratelimit: invalid burst in %q", s)
+	}
+
+	return limit, nil
+}
+
+// Match returns the first route matching the request method and path.
+func (rs Routes) Match(method, path string) (Route, bool) {
+	for _, route := range rs {
+		if route.Method == method && match(route.Pattern, path) {
+			return route, true
+		}
+	}
+	return Route{}, false
+}
+
+func match(pattern, path string) bool {
+	patternParts := strings.Split(strings.Trim(pattern, "/"), "/")
+	pathParts := strings.Split(strings.Trim(path, "/"), "/")
+
+	for i, part := range patternParts {
+		if strings.HasPrefix(part, "*") {
+			return true
+		}
+		if i >= len(pathParts) {
+			return false
+		}
+		if !strings.HasPrefix(part, ":") && part != pathParts[i] {
+			return false
+		}
+	}
+
+	return len(patternParts) == len(pathParts)
+}
@@ -0,0 +1,58 @@
+package thumbnail
+
+import (
+	"image"
+	"image/color"
+)
+
+// Generate scales src down so that neither side exceeds maxSize, keeping the
+// aspect ratio. Each destination pixel is the average of the source pixels it
+// covers. Images already within maxSize are returned unchanged.
+func Generate(src image.Image, maxSize int) image.Image {
+	bounds := src.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+
+	if w <= maxSize && h <= maxSize {
+		return src
+	}
+
+	dw, dh := maxSize, maxSize
+	if w > h {
+		dh = max(1, h*maxSize/w)
+	} else {
+		dw = max(1, w*maxSize/h)
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+
+	for y := 0; y < dh; y++ {
+		sy0 := bounds.Min.Y + y*h/dh
+		sy1 := max(sy0+1, bounds.Min.Y+(y+1)*h/dh)
+
+		for x := 0; x < dw; x++ {
+			sx0 := bounds.Min.X + x*w/dw
+			sx1 := max(sx0+1, bounds.Min.X+(x+1)*w/dw)
+
+			var r, g, b, a, n uint64
+			for sy := sy0; sy < sy1; sy++ {
+				for sx := sx0; sx < sx1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r += uint64(cr)
+					g += uint64(cg)
+					b += uint64(cb)
+					a += uint64(ca)
+					n++
+				}
+			}
+
+			dst.SetRGBA(x, y, color.RGBA{
+				R: uint8(r / n >> 8),
+				G: uint8(g / n >> 8),
+				B: uint8(b / n >> 8),
+				A: uint8(a / n >> 8),
+			})
+		}
+	}
+
+	return dst
+}
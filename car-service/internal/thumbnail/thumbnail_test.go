@@ -0,0 +1,53 @@
+package thumbnail
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		name          string
+		width, height int
+		maxSize       int
+		wantW, wantH  int
+	}{
+		{"Landscape", 1200, 800, 300, 300, 200},
+		{"Portrait", 600, 1800, 300, 100, 300},
+		{"Square", 1000, 1000, 250, 250, 250},
+		{"Already small", 200, 100, 300, 200, 100},
+		{"Very wide", 3000, 2, 300, 300, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := image.NewRGBA(image.Rect(0, 0, tt.width, tt.height))
+
+			got := Generate(src, tt.maxSize).Bounds()
+			if got.Dx() != tt.wantW || got.Dy() != tt.wantH {
+				t.Errorf("got %dx%d want %dx%d", got.Dx(), got.Dy(), tt.wantW, tt.wantH)
+			}
+		})
+	}
+}
+
+func TestGenerateAveragesPixels(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	for x := 0; x < 4; x++ {
+		for y := 0; y < 2; y++ {
+			c := color.RGBA{A: 255}
+			if x%2 == 0 {
+				c.R = 200
+			}
+			src.SetRGBA(x, y, c)
+		}
+	}
+
+	dst := Generate(src, 2)
+
+	r, _, _, _ := dst.At(0, 0).RGBA()
+	if got := uint8(r >> 8); got != 100 {
+		t.Errorf("expected averaged red of 100, got %d", got)
+	}
+}
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS car (
+    id bigserial PRIMARY KEY,
+    created_at timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    brand text NOT NULL,
+    description text NOT NULL DEFAULT '',
+    color text NOT NULL DEFAULT '',
+    year integer NOT NULL DEFAULT 0,
+    price integer NOT NULL,
+    is_used boolean NOT NULL DEFAULT false,
+    owner_id bigint NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS car_brand_idx ON car USING GIN (to_tsvector('simple', brand));
+CREATE INDEX IF NOT EXISTS car_color_idx ON car USING GIN (to_tsvector('simple', color));
+CREATE INDEX IF NOT EXISTS car_owner_id_idx ON car (owner_id);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS rented_cars;
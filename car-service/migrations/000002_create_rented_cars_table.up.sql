@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS rented_cars (
+    id bigserial PRIMARY KEY,
+    user_id bigint NOT NULL,
+    car_id bigint NOT NULL,
+    price integer NOT NULL,
+    taking_date timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    return_date timestamp(0) with time zone
+);
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS rented_cars_user_id_idx;
+DROP INDEX IF EXISTS rented_cars_car_id_status_idx;
+
+ALTER TABLE rented_cars DROP CONSTRAINT IF EXISTS rented_cars_status_check;
+ALTER TABLE rented_cars ALTER COLUMN return_date DROP NOT NULL;
+
+ALTER TABLE rented_cars DROP COLUMN IF EXISTS final_price;
+ALTER TABLE rented_cars DROP COLUMN IF EXISTS returned_at;
+ALTER TABLE rented_cars DROP COLUMN IF EXISTS status;
@@ -0,0 +1,11 @@
+ALTER TABLE rented_cars ADD COLUMN IF NOT EXISTS status text NOT NULL DEFAULT 'active';
+ALTER TABLE rented_cars ADD COLUMN IF NOT EXISTS returned_at timestamp(0) with time zone;
+ALTER TABLE rented_cars ADD COLUMN IF NOT EXISTS final_price bigint;
+
+UPDATE rented_cars SET return_date = taking_date + interval '1 day' WHERE return_date IS NULL;
+ALTER TABLE rented_cars ALTER COLUMN return_date SET NOT NULL;
+
+ALTER TABLE rented_cars ADD CONSTRAINT rented_cars_status_check CHECK (status IN ('active', 'returned'));
+
+CREATE INDEX IF NOT EXISTS rented_cars_car_id_status_idx ON rented_cars (car_id, status, taking_date);
+CREATE INDEX IF NOT EXISTS rented_cars_user_id_idx ON rented_cars (user_id);
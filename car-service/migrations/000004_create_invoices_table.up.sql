@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS invoices (
+    id bigserial PRIMARY KEY,
+    rental_id bigint NOT NULL UNIQUE REFERENCES rented_cars,
+    user_id bigint NOT NULL,
+    car_id bigint NOT NULL,
+    created_at timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    daily_price integer NOT NULL,
+    taking_date timestamp(0) with time zone NOT NULL,
+    return_date timestamp(0) with time zone NOT NULL,
+    returned_at timestamp(0) with time zone NOT NULL,
+    days bigint NOT NULL,
+    hours bigint NOT NULL,
+    late_hours bigint NOT NULL,
+    base_amount bigint NOT NULL,
+    late_fee bigint NOT NULL,
+    total bigint NOT NULL
+);
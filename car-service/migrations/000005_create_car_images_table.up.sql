@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS car_images (
+    id bigserial PRIMARY KEY,
+    car_id bigint NOT NULL REFERENCES car ON DELETE CASCADE,
+    created_at timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    content_type text NOT NULL,
+    key text NOT NULL UNIQUE,
+    thumbnail_key text
+);
+
+CREATE INDEX IF NOT EXISTS car_images_car_id_idx ON car_images (car_id);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS car_year_idx;
+DROP INDEX IF EXISTS car_price_idx;
+DROP INDEX IF EXISTS car_text_idx;
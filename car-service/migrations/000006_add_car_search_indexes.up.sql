@@ -0,0 +1,3 @@
+CREATE INDEX IF NOT EXISTS car_text_idx ON car USING GIN (to_tsvector('simple', brand || ' ' || description));
+CREATE INDEX IF NOT EXISTS car_price_idx ON car (price);
+CREATE INDEX IF NOT EXISTS car_year_idx ON car (year);
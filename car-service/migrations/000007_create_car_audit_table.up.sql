@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS car_audit (
+    id bigserial PRIMARY KEY,
+    car_id bigint NOT NULL REFERENCES car ON DELETE CASCADE,
+    user_id bigint NOT NULL,
+    created_at timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    changes jsonb NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS car_audit_car_id_idx ON car_audit (car_id);
@@ -0,0 +1 @@
+ALTER TABLE car ADD COLUMN IF NOT EXISTS is_used boolean NOT NULL DEFAULT false;
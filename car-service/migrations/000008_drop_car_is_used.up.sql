@@ -0,0 +1 @@
+ALTER TABLE car DROP COLUMN IF EXISTS is_used;
@@ -0,0 +1,2 @@
+-- The outbox table is shared with the other services and owned by the
+-- authorization service, which drops it in its own down migration.
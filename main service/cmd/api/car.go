@@ -1,14 +1,14 @@
 package main
 
 import (
-	"bytes"
-	"encoding/json"
+	"errors"
+	"io"
+	"main_service/internal/client"
 	"net/http"
-	"strconv"
+	"time"
 )
 
 func (app *application) createCarHandler(w http.ResponseWriter, r *http.Request) {
-	user := app.contextGetUser(r)
 	var input struct {
 		Brand       string `json:"brand"`
 		Description string `json:"description"`
@@ -23,89 +23,86 @@ func (app *application) createCarHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	data := struct {
-		Brand       string `json:"brand"`
-		Description string `json:"description"`
-		Color       string `json:"color,omitempty"`
-		Year        int32  `json:"year,omitempty"`
-		Price       int32  `json:"price"`
-		UserID      int64  `json:"user_id"`
-	}{
+	data := client.CarInput{
 		Brand:       input.Brand,
 		Description: input.Description,
 		Color:       input.Color,
 		Year:        input.Year,
 		Price:       input.Price,
-		UserID:      user.ID,
 	}
 
-	jsonData, err := json.Marshal(data)
+	result, status, err := app.cars.Create(r.Context(), data)
 	if err != nil {
-		app.serverErrorResponse(w, r, err)
+		app.upstreamErrorResponse(w, r, err)
 		return
 	}
 
-	request, err := http.NewRequest("POST", "http://localhost:4000/car", bytes.NewBuffer(jsonData))
+	err = app.writeJSON(w, status, envelope(result), nil)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
-		return
 	}
+}
 
-	request.Header.Set("Content-Type", "application/json")
-
-	client := http.Client{}
-	response, err := client.Do(request)
+func (app *application) showCarHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := app.readIDParam(r)
 	if err != nil {
-		app.serverErrorResponse(w, r, err)
+		app.notFoundResponse(w, r)
 		return
 	}
 
-	defer response.Body.Close()
-
-	var result map[string]interface{}
-	err = json.NewDecoder(response.Body).Decode(&result)
+	result, status, err := app.cars.Get(r.Context(), id)
 	if err != nil {
-		app.serverErrorResponse(w, r, err)
+		app.upstreamErrorResponse(w, r, err)
 		return
 	}
 
-	err = app.writeJSON(w, http.StatusOK, envelope{"car": data}, nil)
+	err = app.writeJSON(w, status, envelope(result), nil)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
 	}
 }
 
-func (app *application) showCarHandler(w http.ResponseWriter, r *http.Request) {
+func (app *application) updateCarHandler(w http.ResponseWriter, r *http.Request) {
 	id, err := app.readIDParam(r)
 	if err != nil {
 		app.notFoundResponse(w, r)
 		return
 	}
 
-	request, err := http.NewRequest(http.MethodGet, "http://localhost:4000/car/"+strconv.Itoa(int(id)), nil)
+	var input client.UpdateCarInput
+
+	err = app.readJSON(w, r, &input)
 	if err != nil {
-		app.serverErrorResponse(w, r, err)
+		app.badRequestResponse(w, r, err)
 		return
 	}
 
-	request.Header.Set("Content-Type", "application/json")
+	result, status, err := app.cars.Update(r.Context(), id, input)
+	if err != nil {
+		app.upstreamErrorResponse(w, r, err)
+		return
+	}
 
-	client := http.Client{}
-	response, err := client.Do(request)
+	err = app.writeJSON(w, status, envelope(result), nil)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) listCarAuditHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := app.readIDParam(r)
+	if err != nil {
+		app.notFoundResponse(w, r)
 		return
 	}
-	defer response.Body.Close()
 
-	var result map[string]interface{}
-	err = json.NewDecoder(response.Body).Decode(&result)
+	result, status, err := app.cars.Audit(r.Context(), id)
 	if err != nil {
-		app.serverErrorResponse(w, r, err)
+		app.upstreamErrorResponse(w, r, err)
 		return
 	}
 
-	err = app.writeJSON(w, http.StatusOK, envelope{"car": result}, nil)
+	err = app.writeJSON(w, status, envelope(result), nil)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
 	}
@@ -118,120 +115,125 @@ func (app *application) deleteCarHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	request, err := http.NewRequest(http.MethodDelete, "http://localhost:4000/car/"+strconv.Itoa(int(id)), nil)
+	result, status, err := app.cars.Delete(r.Context(), id)
 	if err != nil {
-		app.serverErrorResponse(w, r, err)
+		app.upstreamErrorResponse(w, r, err)
 		return
 	}
 
-	request.Header.Set("Content-Type", "application/json")
-
-	client := http.Client{}
-	response, err := client.Do(request)
+	err = app.writeJSON(w, status, envelope(result), nil)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
-		return
 	}
-	defer response.Body.Close()
+}
 
-	err = app.writeJSON(w, http.StatusOK, envelope{"message": "car successfully deleted"}, nil)
+func (app *application) uploadCarImageHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := app.readIDParam(r)
 	if err != nil {
-		app.serverErrorResponse(w, r, err)
+		app.notFoundResponse(w, r)
+		return
 	}
-}
 
-func (app *application) listCarHandler(w http.ResponseWriter, r *http.Request) {
-	request, err := http.NewRequest(http.MethodGet, "http://localhost:4000/cars", nil)
+	// Check ownership before reading the upload, so users can't make the
+	// gateway buffer images for cars they don't own.
+	car, err := app.cars.GetOwner(r.Context(), id)
 	if err != nil {
-		app.serverErrorResponse(w, r, err)
+		app.upstreamErrorResponse(w, r, err)
 		return
 	}
 
-	request.Header.Set("Content-Type", "application/json")
-
-	client := http.Client{}
-	response, err := client.Do(request)
-	if err != nil {
-		app.serverErrorResponse(w, r, err)
+	if car.OwnerID != app.contextGetUser(r).ID {
+		app.wrongCarResponse(w, r)
 		return
 	}
-	defer response.Body.Close()
 
-	var result map[string]interface{}
-	err = json.NewDecoder(response.Body).Decode(&result)
+	r.Body = http.MaxBytesReader(w, r.Body, app.config.images.maxBytes+1_048_576)
+
+	err = r.ParseMultipartForm(1_048_576)
 	if err != nil {
-		app.serverErrorResponse(w, r, err)
+		var maxBytesError *http.MaxBytesError
+		switch {
+		case errors.As(err, &maxBytesError):
+			app.fileSizeLimitResponse(w, r)
+		default:
+			app.badRequestResponse(w, r, err)
+		}
 		return
 	}
+	defer r.MultipartForm.RemoveAll()
 
-	err = app.writeJSON(w, http.StatusOK, envelope{"car": result}, nil)
+	file, header, err := r.FormFile("image")
 	if err != nil {
-		app.serverErrorResponse(w, r, err)
+		switch {
+		case errors.Is(err, http.ErrMissingFile):
+			app.failedValidationResponse(w, r, map[string]string{"image": "must be provided"})
+		default:
+			app.badRequestResponse(w, r, err)
+		}
+		return
 	}
-}
+	defer file.Close()
 
-func (app *application) rentCarHandler(w http.ResponseWriter, r *http.Request) {
-	var input struct {
-		UserID int64 `json:"user_id"`
+	if header.Size > app.config.images.maxBytes {
+		app.fileSizeLimitResponse(w, r)
+		return
 	}
 
-	err := app.readJSON(w, r, &input)
+	content, err := io.ReadAll(file)
 	if err != nil {
-		app.badRequestResponse(w, r, err)
+		app.serverErrorResponse(w, r, err)
 		return
 	}
 
-	id, err := app.readIDParam(r)
+	result, status, err := app.cars.UploadImage(r.Context(), id, header.Filename, content)
 	if err != nil {
-		app.notFoundResponse(w, r)
+		app.upstreamErrorResponse(w, r, err)
 		return
 	}
 
-	data := struct {
-		UserID int64 `json:"user_id"`
-	}{
-		UserID: input.UserID,
+	err = app.writeJSON(w, status, envelope(result), nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
 	}
+}
 
-	jsonData, err := json.Marshal(data)
+func (app *application) listCarHandler(w http.ResponseWriter, r *http.Request) {
+	result, status, err := app.cars.List(r.Context(), r.URL.Query())
 	if err != nil {
-		app.serverErrorResponse(w, r, err)
+		app.upstreamErrorResponse(w, r, err)
 		return
 	}
 
-	request, err := http.NewRequest(http.MethodPost, "http://localhost:4000/car/"+strconv.Itoa(int(id))+"/rent", bytes.NewBuffer(jsonData))
+	err = app.writeJSON(w, status, envelope(result), nil)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
-		return
 	}
+}
 
-	request.Header.Set("Content-Type", "application/json")
-
-	client := http.Client{}
-	response, err := client.Do(request)
+func (app *application) showCarAvailabilityHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := app.readIDParam(r)
 	if err != nil {
-		app.serverErrorResponse(w, r, err)
+		app.notFoundResponse(w, r)
 		return
 	}
 
-	defer response.Body.Close()
-
-	var result map[string]interface{}
-	err = json.NewDecoder(response.Body).Decode(&result)
+	result, status, err := app.cars.Availability(r.Context(), id, r.URL.Query())
 	if err != nil {
-		app.serverErrorResponse(w, r, err)
+		app.upstreamErrorResponse(w, r, err)
 		return
 	}
 
-	err = app.writeJSON(w, http.StatusOK, envelope{"car": result}, nil)
+	err = app.writeJSON(w, status, envelope(result), nil)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
 	}
 }
 
-func (app *application) returnRentedCarHandler(w http.ResponseWriter, r *http.Request) {
+func (app *application) rentCarHandler(w http.ResponseWriter, r *http.Request) {
+	user := app.contextGetUser(r)
 	var input struct {
-		UserID int64 `json:"user_id"`
+		TakingDate *time.Time `json:"taking_date"`
+		ReturnDate *time.Time `json:"return_date"`
 	}
 
 	err := app.readJSON(w, r, &input)
@@ -246,43 +248,41 @@ func (app *application) returnRentedCarHandler(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	data := struct {
-		UserID int64 `json:"user_id"`
-	}{
-		UserID: input.UserID,
+	data := client.RentInput{
+		TakingDate: input.TakingDate,
+		ReturnDate: input.ReturnDate,
 	}
 
-	jsonData, err := json.Marshal(data)
+	result, status, err := app.cars.Rent(r.Context(), id, data)
 	if err != nil {
-		app.serverErrorResponse(w, r, err)
+		app.upstreamErrorResponse(w, r, err)
 		return
 	}
 
-	request, err := http.NewRequest(http.MethodPut, "http://localhost:4000/car/"+strconv.Itoa(int(id))+"/return", bytes.NewBuffer(jsonData))
+	app.background(func() {
+		app.sendRentalConfirmation(user.ID, result)
+	})
+
+	err = app.writeJSON(w, status, envelope(result), nil)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
-		return
 	}
+}
 
-	request.Header.Set("Content-Type", "application/json")
-
-	client := http.Client{}
-	response, err := client.Do(request)
+func (app *application) returnRentedCarHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := app.readIDParam(r)
 	if err != nil {
-		app.serverErrorResponse(w, r, err)
+		app.notFoundResponse(w, r)
 		return
 	}
 
-	defer response.Body.Close()
-
-	var result map[string]interface{}
-	err = json.NewDecoder(response.Body).Decode(&result)
+	result, status, err := app.cars.Return(r.Context(), id)
 	if err != nil {
-		app.serverErrorResponse(w, r, err)
+		app.upstreamErrorResponse(w, r, err)
 		return
 	}
 
-	err = app.writeJSON(w, http.StatusOK, envelope{"car": result}, nil)
+	err = app.writeJSON(w, status, envelope(result), nil)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
 	}
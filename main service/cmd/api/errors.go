@@ -1,7 +1,9 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"main_service/internal/client"
 	"net/http"
 )
 
@@ -29,6 +31,35 @@ func (app *application) serverErrorResponse(w http.ResponseWriter, r *http.Reque
 	app.errorResponse(w, r, http.StatusInternalServerError, message)
 }
 
+// upstreamErrorResponse relays a failed call to car-service or user-service.
+// Upstream error responses keep their status and "error" payload, transport
+// failures become 502 or 504 and an open circuit becomes 503.
+func (app *application) upstreamErrorResponse(w http.ResponseWriter, r *http.Request, err error) {
+	var upstreamErr *client.UpstreamError
+
+	switch {
+	case errors.As(err, &upstreamErr):
+		if upstreamErr.Status >= http.StatusInternalServerError {
+			app.logError(r, err)
+		}
+		app.errorResponse(w, r, upstreamErr.Status, upstreamErr.Message)
+	case errors.Is(err, client.ErrCircuitOpen):
+		app.logError(r, err)
+		message := "the upstream service is temporarily unavailable, please try again later"
+		app.errorResponse(w, r, http.StatusServiceUnavailable, message)
+	case errors.Is(err, client.ErrUpstreamTimeout):
+		app.logError(r, err)
+		message := "the upstream service did not respond in time"
+		app.errorResponse(w, r, http.StatusGatewayTimeout, message)
+	case errors.Is(err, client.ErrUpstreamUnavailable), errors.Is(err, client.ErrInvalidResponse):
+		app.logError(r, err)
+		message := "the upstream service is unavailable"
+		app.errorResponse(w, r, http.StatusBadGateway, message)
+	default:
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
 func (app *application) notFoundResponse(w http.ResponseWriter, r *http.Request) {
 	message := "the requested resource could not be found"
 	app.errorResponse(w, r, http.StatusNotFound, message)
@@ -69,13 +100,8 @@ func (app *application) inactiveAccountResponse(w http.ResponseWriter, r *http.R
 	app.errorResponse(w, r, http.StatusForbidden, message)
 }
 
-func (app *application) moderatorRoleRequiredResponse(w http.ResponseWriter, r *http.Request) {
-	message := "you must have moderator or admin role to access this resource"
-	app.errorResponse(w, r, http.StatusForbidden, message)
-}
-
-func (app *application) adminRoleRequiredResponse(w http.ResponseWriter, r *http.Request) {
-	message := "you must have admin role to access this resource"
+func (app *application) notPermittedResponse(w http.ResponseWriter, r *http.Request) {
+	message := "your user account doesn't have the necessary permissions to access this resource"
 	app.errorResponse(w, r, http.StatusForbidden, message)
 }
 
@@ -94,6 +120,11 @@ func (app *application) carNotUsedResponse(w http.ResponseWriter, r *http.Reques
 	app.errorResponse(w, r, http.StatusForbidden, message)
 }
 
+func (app *application) rateLimitExceededResponse(w http.ResponseWriter, r *http.Request) {
+	message := "rate limit exceeded"
+	app.errorResponse(w, r, http.StatusTooManyRequests, message)
+}
+
 func (app *application) fileSizeLimitResponse(w http.ResponseWriter, r *http.Request) {
 	message := "the file exceeds the maximum allowed memory size"
 	app.errorResponse(w, r, http.StatusNotAcceptable, message)
@@ -134,3 +134,9 @@ func (app *application) background(fn func()) {
 		fn()
 	}()
 }
+
+// bearerToken returns the token from the Authorization header, which the
+// authenticate middleware has already checked to be well formed.
+func (app *application) bearerToken(r *http.Request) string {
+	return strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+}
@@ -2,20 +2,58 @@ package main
 
 import (
 	"flag"
+	"main_service/internal/client"
+	"main_service/internal/conf"
 	"main_service/internal/jsonlog"
+	"main_service/internal/ratelimit"
+	"net"
+	"net/http"
 	"os"
 	"sync"
+	"time"
 )
 
 type config struct {
-	port int
-	env  string
+	port       int
+	env        string
+	carService struct {
+		url     string
+		timeout time.Duration
+	}
+	userService struct {
+		url     string
+		timeout time.Duration
+	}
+	images struct {
+		maxBytes int64
+	}
+	upstream struct {
+		retries          int
+		retryBaseDelay   time.Duration
+		retryMaxDelay    time.Duration
+		breakerThreshold int
+		breakerCooldown  time.Duration
+	}
+	principal struct {
+		secret string
+	}
+	limiter struct {
+		enabled        bool
+		rps            float64
+		burst          int
+		byUser         bool
+		routes         ratelimit.Routes
+		trustedProxies []*net.IPNet
+	}
 }
 
 type application struct {
-	config config
-	logger *jsonlog.Logger
-	wg     sync.WaitGroup
+	config  config
+	logger  *jsonlog.Logger
+	cars    *client.CarClient
+	users   *client.UserClient
+	limiter *ratelimit.Limiter
+	wg      sync.WaitGroup
 }
 
 func main() {
@@ -24,17 +62,86 @@ func main() {
 	flag.IntVar(&cfg.port, "port", 4002, "Main server port")
 	flag.StringVar(&cfg.env, "env", "development", "Environment (development|staging|production)")
 
-	flag.Parse()
+	flag.StringVar(&cfg.carService.url, "car-service-url", "http://localhost:4000", "Car service base URL")
+	flag.DurationVar(&cfg.carService.timeout, "car-service-timeout", 10*time.Second, "Car service request timeout")
+	flag.StringVar(&cfg.userService.url, "user-service-url", "http://localhost:4001", "User service base URL")
+	flag.DurationVar(&cfg.userService.timeout, "user-service-timeout", 10*time.Second, "User service request timeout")
+
+	flag.Int64Var(&cfg.images.maxBytes, "images-max-bytes", 10<<20, "Maximum size of an uploaded car image in bytes")
+
+	flag.IntVar(&cfg.upstream.retries, "upstream-retries", 2, "Retries for idempotent upstream requests")
+	flag.DurationVar(&cfg.upstream.retryBaseDelay, "upstream-retry-base-delay", 100*time.Millisecond, "Initial upstream retry backoff")
+	flag.DurationVar(&cfg.upstream.retryMaxDelay, "upstream-retry-max-delay", time.Second, "Maximum upstream retry backoff")
+	flag.IntVar(&cfg.upstream.breakerThreshold, "upstream-breaker-threshold", 5, "Consecutive upstream failures before the circuit opens (0 disables)")
+	flag.DurationVar(&cfg.upstream.breakerCooldown, "upstream-breaker-cooldown", 30*time.Second, "Time an open circuit waits before a half-open probe")
+
+	flag.StringVar(&cfg.principal.secret, "principal-secret", "", "Secret shared with the car service to sign the principal header (required)")
+
+	flag.BoolVar(&cfg.limiter.enabled, "limiter-enabled", true, "Enable per-client rate limiting")
+	flag.Float64Var(&cfg.limiter.rps, "limiter-rps", 10, "Rate limiter maximum requests per second")
+	flag.IntVar(&cfg.limiter.burst, "limiter-burst", 20, "Rate limiter maximum burst")
+	flag.BoolVar(&cfg.limiter.byUser, "limiter-by-user", true, "Also rate limit authenticated requests per user")
+	limiterRoutes := flag.String("limiter-routes", "POST /tokens/authentication=0.2:5,POST /tokens/refresh=0.2:5,POST /tokens/password-reset=0.05:3,POST /users=0.05:3", "Per-route limits as \"METHOD /path=rps:burst\" (or =off), comma separated")
+	limiterTrustedProxies := flag.String("limiter-trusted-proxies", "", "Comma separated proxy addresses or ranges whose X-Forwarded-For is trusted")
 
 	logger := jsonlog.New(os.Stdout, jsonlog.LevelInfo)
 
+	err := conf.Load(flag.CommandLine, os.Args[1:])
+	if err != nil {
+		logger.PrintFatal(err, nil)
+	}
+
+	cfg.limiter.routes, err = ratelimit.ParseRoutes(*limiterRoutes)
+	if err != nil {
+		logger.PrintFatal(err, nil)
+	}
+
+	cfg.limiter.trustedProxies, err = ratelimit.ParseTrustedProxies(*limiterTrustedProxies)
+	if err != nil {
+		logger.PrintFatal(err, nil)
+	}
+
+	err = conf.Require(flag.CommandLine, "car-service-url", "user-service-url", "principal-secret")
+	if err != nil {
+		logger.PrintFatal(err, nil)
+	}
+
+	logger.PrintInfo("configuration loaded", conf.Effective(flag.CommandLine, "principal-secret"))
+
+	httpClient := &http.Client{}
+
 	app := &application{
 		config: cfg,
 		logger: logger,
+		cars:   client.NewCarClient(httpClient, upstreamOptions(cfg, "car-service", cfg.carService.url, cfg.carService.timeout, logger)),
+		users:  client.NewUserClient(httpClient, upstreamOptions(cfg, "user-service", cfg.userService.url, cfg.userService.timeout, logger)),
+	}
+
+	if cfg.limiter.enabled {
+		app.limiter = ratelimit.New()
 	}
 
-	err := app.serve()
+	err = app.serve()
 	if err != nil {
 		logger.PrintFatal(err, nil)
 	}
 }
+
+func upstreamOptions(cfg config, name, url string, timeout time.Duration, logger *jsonlog.Logger) client.Options {
+	return client.Options{
+		Name:    name,
+		BaseURL: url,
+		Timeout: timeout,
+		Retry: client.RetryOptions{
+			MaxAttempts: cfg.upstream.retries + 1,
+			BaseDelay:   cfg.upstream.retryBaseDelay,
+			MaxDelay:    cfg.upstream.retryMaxDelay,
+		},
+		Breaker: client.BreakerOptions{
+			Threshold: cfg.upstream.breakerThreshold,
+			Cooldown:  cfg.upstream.breakerCooldown,
+		},
+		Logger:          logger,
+		PrincipalSecret: cfg.principal.secret,
+	}
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"errors"
+	"main_service/internal/client"
+	"main_service/internal/models"
+	"main_service/internal/principal"
+	"main_service/internal/ratelimit"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+func (app *application) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Authorization")
+
+		authorizationHeader := r.Header.Get("Authorization")
+
+		if authorizationHeader == "" {
+			r = app.contextSetUser(r, models.AnonymousUser)
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		headerParts := strings.Split(authorizationHeader, " ")
+		if len(headerParts) != 2 || headerParts[0] != "Bearer" {
+			app.invalidAuthenticationTokenResponse(w, r)
+			return
+		}
+
+		user, err := app.users.Introspect(r.Context(), headerParts[1])
+		if err != nil {
+			switch {
+			case errors.Is(err, client.ErrInvalidToken):
+				app.invalidAuthenticationTokenResponse(w, r)
+			default:
+				app.upstreamErrorResponse(w, r, err)
+			}
+			return
+		}
+
+		r = app.contextSetUser(r, user)
+		r = r.WithContext(client.WithPrincipal(r.Context(), principal.Principal{
+			UserID:      user.ID,
+			Permissions: user.Permissions,
+		}))
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (app *application) requireAuthenticatedUser(next http.HandlerFunc) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user := app.contextGetUser(r)
+
+		if user.IsAnonymous() {
+			app.authenticationRequiredResponse(w, r)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (app *application) requireActivatedUser(next http.HandlerFunc) http.HandlerFunc {
+	fn := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user := app.contextGetUser(r)
+
+		if !user.Activated {
+			app.inactiveAccountResponse(w, r)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+
+	return app.requireAuthenticatedUser(fn)
+}
+
+func (app *application) requirePermission(code string, next http.HandlerFunc) http.HandlerFunc {
+	fn := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user := app.contextGetUser(r)
+
+		if !user.HasPermission(code) {
+			app.notPermittedResponse(w, r)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+
+	return app.requireActivatedUser(fn)
+}
+
+// rateLimit applies the default limit, or the one configured for the matching
+// route, to each client IP. It runs before authenticate so that requests with
+// bad tokens are limited before they cost an introspection call. The client IP
+// is also passed on to the upstream services.
+func (app *application) rateLimit(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ip := ratelimit.ClientIP(r, app.config.limiter.trustedProxies)
+		r = r.WithContext(client.WithClientIP(r.Context(), ip))
+
+		if app.limiter != nil && !app.allow(w, r, "ip:"+ip) {
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// rateLimitUser additionally limits authenticated requests per user when
+// -limiter-by-user is set, so one account can't spread its requests over many
+// addresses.
+func (app *application) rateLimitUser(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user := app.contextGetUser(r)
+
+		if app.limiter == nil || !app.config.limiter.byUser || user.IsAnonymous() {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if !app.allow(w, r, "user:"+strconv.FormatInt(user.ID, 10)) {
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// allow takes a token from the identity's bucket for the matching route and
+// writes the rate limit response when there is none left.
+func (app *application) allow(w http.ResponseWriter, r *http.Request, identity string) bool {
+	limit := ratelimit.Limit{RPS: app.config.limiter.rps, Burst: app.config.limiter.burst}
+	key := "*"
+
+	if route, ok := app.config.limiter.routes.Match(r.Method, r.URL.Path); ok {
+		limit = route.Limit
+		key = route.Key()
+	}
+
+	if limit.Unlimited() {
+		return true
+	}
+
+	result := app.limiter.Allow(key+"|"+identity, limit)
+	ratelimit.SetHeaders(w.Header(), result)
+
+	if !result.Allowed {
+		app.rateLimitExceededResponse(w, r)
+		return false
+	}
+
+	return true
+}
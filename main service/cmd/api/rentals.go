@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"main_service/internal/client"
+	"main_service/internal/models"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+func (app *application) showRentalInvoiceHandler(w http.ResponseWriter, r *http.Request) {
+	user := app.contextGetUser(r)
+	id, err := app.readIDParam(r)
+	if err != nil {
+		app.notFoundResponse(w, r)
+		return
+	}
+
+	result, status, err := app.cars.Invoice(r.Context(), id)
+	if err != nil {
+		app.upstreamErrorResponse(w, r, err)
+		return
+	}
+
+	invoice, _ := result["invoice"].(map[string]any)
+
+	userID, _ := invoice["user_id"].(float64)
+	if int64(userID) != user.ID && !user.HasPermission(models.PermissionRentalsRead) {
+		app.notFoundResponse(w, r)
+		return
+	}
+
+	err = app.writeJSON(w, status, envelope{"invoice": invoice}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) listUserRentalsHandler(w http.ResponseWriter, r *http.Request) {
+	user := app.contextGetUser(r)
+	id, err := app.readIDParam(r)
+	if err != nil {
+		app.notFoundResponse(w, r)
+		return
+	}
+
+	if id != user.ID && !user.HasPermission(models.PermissionRentalsRead) {
+		app.notFoundResponse(w, r)
+		return
+	}
+
+	result, status, err := app.cars.UserRentals(r.Context(), id, r.URL.Query())
+	if err != nil {
+		app.upstreamErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, status, envelope(result), nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) listCarRentalsHandler(w http.ResponseWriter, r *http.Request) {
+	user := app.contextGetUser(r)
+	id, err := app.readIDParam(r)
+	if err != nil {
+		app.notFoundResponse(w, r)
+		return
+	}
+
+	car, err := app.cars.GetOwner(r.Context(), id)
+	if err != nil {
+		app.upstreamErrorResponse(w, r, err)
+		return
+	}
+
+	if car.OwnerID != user.ID && !user.HasPermission(models.PermissionRentalsRead) {
+		app.wrongCarResponse(w, r)
+		return
+	}
+
+	result, status, err := app.cars.CarRentals(r.Context(), id, r.URL.Query())
+	if err != nil {
+		app.upstreamErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, status, envelope(result), nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// sendRentalConfirmation asks user-service to email the renter the details of
+// a rental that car-service has just accepted.
+func (app *application) sendRentalConfirmation(userID int64, result client.Envelope) {
+	var rent struct {
+		Car struct {
+			ID    int64  `json:"id"`
+			Brand string `json:"brand"`
+		} `json:"car"`
+		Rental struct {
+			Price      int32     `json:"price"`
+			TakingDate time.Time `json:"taking_date"`
+			ReturnDate time.Time `json:"return_date"`
+		} `json:"rental"`
+	}
+
+	err := result.Decode(&rent)
+	if err != nil {
+		app.logger.PrintError(err, nil)
+		return
+	}
+
+	data := client.RentalConfirmationInput{
+		CarID:      rent.Car.ID,
+		CarBrand:   rent.Car.Brand,
+		Price:      rent.Rental.Price,
+		TakingDate: rent.Rental.TakingDate,
+		ReturnDate: rent.Rental.ReturnDate,
+	}
+
+	_, _, err = app.users.SendRentalConfirmation(context.Background(), userID, data)
+	if err != nil {
+		app.logger.PrintError(err, map[string]string{
+			"user_id": strconv.FormatInt(userID, 10),
+		})
+	}
+}
@@ -2,10 +2,11 @@ package main
 
 import (
 	"github.com/julienschmidt/httprouter"
+	"main_service/internal/models"
 	"net/http"
 )
 
-func (app *application) routes() *httprouter.Router {
+func (app *application) routes() http.Handler {
 	router := httprouter.New()
 
 	router.NotFound = http.HandlerFunc(app.notFoundResponse)
@@ -13,18 +14,36 @@ func (app *application) routes() *httprouter.Router {
 
 	router.HandlerFunc(http.MethodGet, "/car/:id", app.showCarHandler)
 	router.HandlerFunc(http.MethodGet, "/cars", app.listCarHandler)
-	router.HandlerFunc(http.MethodPost, "/car", app.createCarHandler)
-	router.HandlerFunc(http.MethodDelete, "/car/:id", app.deleteCarHandler)
+	router.HandlerFunc(http.MethodPost, "/car", app.requirePermission(models.PermissionCarsWrite, app.createCarHandler))
+	router.HandlerFunc(http.MethodPatch, "/car/:id", app.requirePermission(models.PermissionCarsWrite, app.updateCarHandler))
+	router.HandlerFunc(http.MethodDelete, "/car/:id", app.requirePermission(models.PermissionCarsWrite, app.deleteCarHandler))
+	router.HandlerFunc(http.MethodGet, "/car/:id/audit", app.requirePermission(models.PermissionCarsWrite, app.listCarAuditHandler))
 
-	router.HandlerFunc(http.MethodPost, "/car/:id/rent", app.rentCarHandler)
-	router.HandlerFunc(http.MethodPut, "/car/:id/return", app.returnRentedCarHandler)
+	router.HandlerFunc(http.MethodPost, "/car/:id/images", app.requirePermission(models.PermissionCarsWrite, app.uploadCarImageHandler))
+
+	router.HandlerFunc(http.MethodGet, "/car/:id/availability", app.showCarAvailabilityHandler)
+	router.HandlerFunc(http.MethodPost, "/car/:id/rent", app.requireActivatedUser(app.rentCarHandler))
+	router.HandlerFunc(http.MethodPut, "/car/:id/return", app.requireActivatedUser(app.returnRentedCarHandler))
+
+	router.HandlerFunc(http.MethodGet, "/car/:id/rentals", app.requireActivatedUser(app.listCarRentalsHandler))
+	router.HandlerFunc(http.MethodGet, "/users/:id/rentals", app.requireActivatedUser(app.listUserRentalsHandler))
+	router.HandlerFunc(http.MethodGet, "/rentals/:id/invoice", app.requireActivatedUser(app.showRentalInvoiceHandler))
 
 	router.HandlerFunc(http.MethodPost, "/users", app.registerUserHandler)
 	router.HandlerFunc(http.MethodPut, "/users/activated", app.activateUserHandler)
-	router.HandlerFunc(http.MethodGet, "/users/:id", app.showUserHandler)
-	router.HandlerFunc(http.MethodDelete, "/users/:id", app.deleteUserHandler)
+	router.HandlerFunc(http.MethodPut, "/users/password", app.updateUserPasswordHandler)
+	router.HandlerFunc(http.MethodGet, "/users/:id", app.requirePermission(models.PermissionUsersRead, app.showUserHandler))
+	router.HandlerFunc(http.MethodDelete, "/users/:id", app.requirePermission(models.PermissionUsersAdmin, app.deleteUserHandler))
+	router.HandlerFunc(http.MethodPut, "/users/roles/:id", app.requirePermission(models.PermissionUsersAdmin, app.setUserRolesHandler))
+	router.HandlerFunc(http.MethodDelete, "/users/:id/lockout", app.requirePermission(models.PermissionUsersAdmin, app.unlockUserHandler))
+	router.HandlerFunc(http.MethodGet, "/users/:id/login-attempts", app.requirePermission(models.PermissionUsersAdmin, app.listLoginAttemptsHandler))
 
 	router.HandlerFunc(http.MethodPost, "/tokens/authentication", app.createAuthenticationTokenHandler)
+	router.HandlerFunc(http.MethodGet, "/tokens/authentication", app.requireAuthenticatedUser(app.listAuthenticationTokensHandler))
+	router.HandlerFunc(http.MethodDelete, "/tokens/authentication", app.requireAuthenticatedUser(app.revokeAuthenticationTokenHandler))
+	router.HandlerFunc(http.MethodDelete, "/tokens/authentication/all", app.requireAuthenticatedUser(app.revokeAllAuthenticationTokensHandler))
+	router.HandlerFunc(http.MethodPost, "/tokens/refresh", app.refreshAuthenticationTokenHandler)
+	router.HandlerFunc(http.MethodPost, "/tokens/password-reset", app.createPasswordResetTokenHandler)
 
-	return router
+	return app.rateLimit(app.authenticate(app.rateLimitUser(router)))
 }
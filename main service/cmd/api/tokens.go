@@ -1,8 +1,7 @@
 package main
 
 import (
-	"bytes"
-	"encoding/json"
+	"main_service/internal/client"
 	"net/http"
 )
 
@@ -18,45 +17,111 @@ func (app *application) createAuthenticationTokenHandler(w http.ResponseWriter,
 		return
 	}
 
-	data := struct {
-		Email    string `json:"email"`
-		Password string `json:"password"`
-	}{
+	data := client.CredentialsInput{
 		Email:    input.Email,
 		Password: input.Password,
 	}
 
-	jsonData, err := json.Marshal(data)
+	result, status, err := app.users.CreateAuthenticationToken(r.Context(), data)
+	if err != nil {
+		app.upstreamErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, status, envelope(result), nil)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) refreshAuthenticationTokenHandler(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		RefreshToken string `json:"refresh_token"`
+	}
+
+	err := app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
 		return
 	}
 
-	request, err := http.NewRequest("POST", "http://localhost:4001/tokens/authentication", bytes.NewBuffer(jsonData))
+	data := client.RefreshInput{
+		RefreshToken: input.RefreshToken,
+	}
+
+	result, status, err := app.users.RefreshAuthenticationToken(r.Context(), data)
+	if err != nil {
+		app.upstreamErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, status, envelope(result), nil)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) createPasswordResetTokenHandler(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Email string `json:"email"`
+	}
+
+	err := app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
 		return
 	}
 
-	request.Header.Set("Content-Type", "application/json")
+	data := client.EmailInput{
+		Email: input.Email,
+	}
 
-	client := http.Client{}
-	response, err := client.Do(request)
+	result, status, err := app.users.CreatePasswordResetToken(r.Context(), data)
+	if err != nil {
+		app.upstreamErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, status, envelope(result), nil)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) listAuthenticationTokensHandler(w http.ResponseWriter, r *http.Request) {
+	result, status, err := app.users.Sessions(r.Context(), app.bearerToken(r))
+	if err != nil {
+		app.upstreamErrorResponse(w, r, err)
 		return
 	}
 
-	defer response.Body.Close()
+	err = app.writeJSON(w, status, envelope(result), nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) revokeAuthenticationTokenHandler(w http.ResponseWriter, r *http.Request) {
+	result, status, err := app.users.RevokeAuthenticationToken(r.Context(), app.bearerToken(r))
+	if err != nil {
+		app.upstreamErrorResponse(w, r, err)
+		return
+	}
 
-	var result map[string]interface{}
-	err = json.NewDecoder(response.Body).Decode(&result)
+	err = app.writeJSON(w, status, envelope(result), nil)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) revokeAllAuthenticationTokensHandler(w http.ResponseWriter, r *http.Request) {
+	result, status, err := app.users.RevokeAllAuthenticationTokens(r.Context(), app.bearerToken(r))
+	if err != nil {
+		app.upstreamErrorResponse(w, r, err)
 		return
 	}
 
-	err = app.writeJSON(w, http.StatusCreated, envelope{"authentication_token": result}, nil)
+	err = app.writeJSON(w, status, envelope(result), nil)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
 	}
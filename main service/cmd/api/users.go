@@ -1,10 +1,8 @@
 package main
 
 import (
-	"bytes"
-	"encoding/json"
+	"main_service/internal/client"
 	"net/http"
-	"strconv"
 )
 
 func (app *application) registerUserHandler(w http.ResponseWriter, r *http.Request) {
@@ -21,56 +19,55 @@ func (app *application) registerUserHandler(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	data := struct {
-		Name     string `json:"name"`
-		Surname  string `json:"surname"`
-		Email    string `json:"email"`
-		Password string `json:"password"`
-	}{
+	data := client.RegisterInput{
 		Name:     input.Name,
 		Surname:  input.Surname,
 		Email:    input.Email,
 		Password: input.Password,
 	}
 
-	jsonData, err := json.Marshal(data)
+	result, status, err := app.users.Register(r.Context(), data)
 	if err != nil {
-		app.serverErrorResponse(w, r, err)
+		app.upstreamErrorResponse(w, r, err)
 		return
 	}
 
-	request, err := http.NewRequest("POST", "http://localhost:4001/users", bytes.NewBuffer(jsonData))
+	err = app.writeJSON(w, status, envelope(result), nil)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
-		return
 	}
+}
 
-	request.Header.Set("Content-Type", "application/json")
+func (app *application) activateUserHandler(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		TokenPlaintext string `json:"token"`
+	}
 
-	client := http.Client{}
-	response, err := client.Do(request)
+	err := app.readJSON(w, r, &input)
 	if err != nil {
-		app.serverErrorResponse(w, r, err)
+		app.badRequestResponse(w, r, err)
 		return
 	}
 
-	defer response.Body.Close()
+	data := client.TokenInput{
+		TokenPlaintext: input.TokenPlaintext,
+	}
 
-	var result map[string]interface{}
-	err = json.NewDecoder(response.Body).Decode(&result)
+	result, status, err := app.users.Activate(r.Context(), data)
 	if err != nil {
-		app.serverErrorResponse(w, r, err)
+		app.upstreamErrorResponse(w, r, err)
 		return
 	}
 
-	err = app.writeJSON(w, http.StatusAccepted, envelope{"result": result}, nil)
+	err = app.writeJSON(w, status, envelope(result), nil)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
 	}
 }
 
-func (app *application) activateUserHandler(w http.ResponseWriter, r *http.Request) {
+func (app *application) updateUserPasswordHandler(w http.ResponseWriter, r *http.Request) {
 	var input struct {
+		Password       string `json:"password"`
 		TokenPlaintext string `json:"token"`
 	}
 
@@ -80,108 +77,123 @@ func (app *application) activateUserHandler(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	data := struct {
-		TokenPlaintext string `json:"token"`
-	}{
+	data := client.PasswordResetInput{
+		Password:       input.Password,
 		TokenPlaintext: input.TokenPlaintext,
 	}
 
-	jsonData, err := json.Marshal(data)
+	result, status, err := app.users.ResetPassword(r.Context(), data)
 	if err != nil {
-		app.serverErrorResponse(w, r, err)
+		app.upstreamErrorResponse(w, r, err)
 		return
 	}
 
-	request, err := http.NewRequest("PUT", "http://localhost:4001/users/activated", bytes.NewBuffer(jsonData))
+	err = app.writeJSON(w, status, envelope(result), nil)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
-		return
 	}
+}
 
-	request.Header.Set("Content-Type", "application/json")
-
-	client := http.Client{}
-	response, err := client.Do(request)
+func (app *application) deleteUserHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := app.readIDParam(r)
 	if err != nil {
-		app.serverErrorResponse(w, r, err)
+		app.notFoundResponse(w, r)
 		return
 	}
 
-	defer response.Body.Close()
-
-	var result map[string]interface{}
-	err = json.NewDecoder(response.Body).Decode(&result)
+	result, status, err := app.users.Delete(r.Context(), id)
 	if err != nil {
-		app.serverErrorResponse(w, r, err)
+		app.upstreamErrorResponse(w, r, err)
 		return
 	}
 
-	err = app.writeJSON(w, http.StatusOK, envelope{"result": result}, nil)
+	err = app.writeJSON(w, status, envelope(result), nil)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
 	}
 }
 
-func (app *application) deleteUserHandler(w http.ResponseWriter, r *http.Request) {
+func (app *application) setUserRolesHandler(w http.ResponseWriter, r *http.Request) {
 	id, err := app.readIDParam(r)
 	if err != nil {
 		app.notFoundResponse(w, r)
 		return
 	}
 
-	request, err := http.NewRequest(http.MethodDelete, "http://localhost:4001/users/"+strconv.Itoa(int(id)), nil)
+	var input struct {
+		Roles []string `json:"roles"`
+	}
+
+	err = app.readJSON(w, r, &input)
 	if err != nil {
-		app.serverErrorResponse(w, r, err)
+		app.badRequestResponse(w, r, err)
 		return
 	}
 
-	request.Header.Set("Content-Type", "application/json")
-
-	client := http.Client{}
-	response, err := client.Do(request)
+	result, status, err := app.users.SetRoles(r.Context(), id, client.RolesInput{Roles: input.Roles})
 	if err != nil {
-		app.serverErrorResponse(w, r, err)
+		app.upstreamErrorResponse(w, r, err)
 		return
 	}
-	defer response.Body.Close()
 
-	err = app.writeJSON(w, http.StatusOK, envelope{"message": "user successfully deleted"}, nil)
+	err = app.writeJSON(w, status, envelope(result), nil)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
 	}
 }
 
-func (app *application) showUserHandler(w http.ResponseWriter, r *http.Request) {
+func (app *application) unlockUserHandler(w http.ResponseWriter, r *http.Request) {
 	id, err := app.readIDParam(r)
 	if err != nil {
 		app.notFoundResponse(w, r)
 		return
 	}
 
-	request, err := http.NewRequest(http.MethodGet, "http://localhost:4001/users/"+strconv.Itoa(int(id)), nil)
+	result, status, err := app.users.Unlock(r.Context(), id)
+	if err != nil {
+		app.upstreamErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, status, envelope(result), nil)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) listLoginAttemptsHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := app.readIDParam(r)
+	if err != nil {
+		app.notFoundResponse(w, r)
 		return
 	}
 
-	request.Header.Set("Content-Type", "application/json")
+	result, status, err := app.users.LoginAttempts(r.Context(), id, r.URL.Query())
+	if err != nil {
+		app.upstreamErrorResponse(w, r, err)
+		return
+	}
 
-	client := http.Client{}
-	response, err := client.Do(request)
+	err = app.writeJSON(w, status, envelope(result), nil)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) showUserHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := app.readIDParam(r)
+	if err != nil {
+		app.notFoundResponse(w, r)
 		return
 	}
-	defer response.Body.Close()
 
-	var result map[string]interface{}
-	err = json.NewDecoder(response.Body).Decode(&result)
+	result, status, err := app.users.Get(r.Context(), id)
 	if err != nil {
-		app.serverErrorResponse(w, r, err)
+		app.upstreamErrorResponse(w, r, err)
 		return
 	}
 
-	err = app.writeJSON(w, http.StatusOK, envelope{"user": result}, nil)
+	err = app.writeJSON(w, status, envelope(result), nil)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
 	}
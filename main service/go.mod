@@ -6,3 +6,5 @@ require (
 	github.com/julienschmidt/httprouter v1.3.0
 	golang.org/x/crypto v0.10.0
 )
+
+require gopkg.in/yaml.v3 v3.0.1
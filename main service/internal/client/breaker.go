@@ -0,0 +1,143 @@
+package client
+
+import (
+	"errors"
+	"main_service/internal/jsonlog"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+type breakerState int
+
+const (
+	stateClosed breakerState = iota
+	stateOpen
+	stateHalfOpen
+)
+
+func (s breakerState) String() string {
+	switch s {
+	case stateClosed:
+		return "closed"
+	case stateOpen:
+		return "open"
+	case stateHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+type BreakerOptions struct {
+	Threshold int
+	Cooldown  time.Duration
+}
+
+// breaker is a consecutive-failure circuit breaker. After Threshold failures
+// in a row it opens and rejects calls for Cooldown, then lets a single probe
+// through in the half-open state; the probe's outcome closes or reopens it.
+type breaker struct {
+	name      string
+	threshold int
+	cooldown  time.Duration
+	logger    *jsonlog.Logger
+	now       func() time.Time
+
+	mu       sync.Mutex
+	state    breakerState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+func newBreaker(name string, opts BreakerOptions, logger *jsonlog.Logger) *breaker {
+	return &breaker{
+		name:      name,
+		threshold: opts.Threshold,
+		cooldown:  opts.Cooldown,
+		logger:    logger,
+		now:       time.Now,
+	}
+}
+
+// allow reports whether a call may proceed. Every allowed call must be
+// followed by exactly one call to done.
+func (b *breaker) allow() error {
+	if b.threshold <= 0 {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case stateOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			return ErrCircuitOpen
+		}
+		b.setState(stateHalfOpen)
+		fallthrough
+	case stateHalfOpen:
+		if b.probing {
+			return ErrCircuitOpen
+		}
+		b.probing = true
+	}
+
+	return nil
+}
+
+func (b *breaker) done(success bool) {
+	if b.threshold <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+
+	if success {
+		b.failures = 0
+		if b.state != stateClosed {
+			b.setState(stateClosed)
+		}
+		return
+	}
+
+	b.failures++
+	if b.state == stateHalfOpen || b.failures >= b.threshold {
+		b.openedAt = b.now()
+		if b.state != stateOpen {
+			b.setState(stateOpen)
+		}
+	}
+}
+
+// release ends an allowed call without recording an outcome, for calls the
+// caller abandoned before the upstream answered.
+func (b *breaker) release() {
+	if b.threshold <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
+
+func (b *breaker) setState(state breakerState) {
+	b.state = state
+
+	if b.logger != nil {
+		b.logger.PrintInfo("circuit breaker state changed", map[string]string{
+			"upstream": b.name,
+			"state":    state.String(),
+			"failures": strconv.Itoa(b.failures),
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+type CarClient struct {
+	client
+}
+
+func NewCarClient(httpClient *http.Client, opts Options) *CarClient {
+	return &CarClient{client: newClient(httpClient, opts)}
+}
+
+type CarInput struct {
+	Brand       string `json:"brand"`
+	Description string `json:"description"`
+	Color       string `json:"color,omitempty"`
+	Year        int32  `json:"year,omitempty"`
+	Price       int32  `json:"price"`
+}
+
+// UpdateCarInput holds a partial update; nil fields are left unchanged.
+type UpdateCarInput struct {
+	Brand       *string `json:"brand,omitempty"`
+	Description *string `json:"description,omitempty"`
+	Color       *string `json:"color,omitempty"`
+	Year        *int32  `json:"year,omitempty"`
+	Price       *int32  `json:"price,omitempty"`
+}
+
+type RentInput struct {
+	TakingDate *time.Time `json:"taking_date,omitempty"`
+	ReturnDate *time.Time `json:"return_date,omitempty"`
+}
+
+type Car struct {
+	ID      int64 `json:"id"`
+	OwnerID int64 `json:"owner_id"`
+}
+
+func (c *CarClient) Create(ctx context.Context, input CarInput) (Envelope, int, error) {
+	var result Envelope
+	status, err := c.do(ctx, http.MethodPost, "/car", nil, input, &result)
+	return result, status, err
+}
+
+// UploadImage forwards an image upload as a multipart form.
+func (c *CarClient) UploadImage(ctx context.Context, id int64, filename string, content []byte) (Envelope, int, error) {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+
+	part, err := form.CreateFormFile("image", filename)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	_, err = part.Write(content)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = form.Close()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var result Envelope
+	status, err := c.doRaw(ctx, http.MethodPost, fmt.Sprintf("/car/%d/images", id), nil, form.FormDataContentType(), body.Bytes(), &result)
+	return result, status, err
+}
+
+func (c *CarClient) Get(ctx context.Context, id int64) (Envelope, int, error) {
+	var result Envelope
+	status, err := c.do(ctx, http.MethodGet, fmt.Sprintf("/car/%d", id), nil, nil, &result)
+	return result, status, err
+}
+
+// GetOwner fetches the car with only the fields the gateway needs to make
+// authorization decisions.
+func (c *CarClient) GetOwner(ctx context.Context, id int64) (*Car, error) {
+	var result struct {
+		Car Car `json:"car"`
+	}
+	_, err := c.do(ctx, http.MethodGet, fmt.Sprintf("/car/%d", id), nil, nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result.Car, nil
+}
+
+func (c *CarClient) Update(ctx context.Context, id int64, input UpdateCarInput) (Envelope, int, error) {
+	var result Envelope
+	status, err := c.do(ctx, http.MethodPatch, fmt.Sprintf("/car/%d", id), nil, input, &result)
+	return result, status, err
+}
+
+func (c *CarClient) Audit(ctx context.Context, id int64) (Envelope, int, error) {
+	var result Envelope
+	status, err := c.do(ctx, http.MethodGet, fmt.Sprintf("/car/%d/audit", id), nil, nil, &result)
+	return result, status, err
+}
+
+func (c *CarClient) Delete(ctx context.Context, id int64) (Envelope, int, error) {
+	var result Envelope
+	status, err := c.do(ctx, http.MethodDelete, fmt.Sprintf("/car/%d", id), nil, nil, &result)
+	return result, status, err
+}
+
+func (c *CarClient) List(ctx context.Context, query url.Values) (Envelope, int, error) {
+	var result Envelope
+	status, err := c.do(ctx, http.MethodGet, "/cars", query, nil, &result)
+	return result, status, err
+}
+
+func (c *CarClient) Availability(ctx context.Context, id int64, query url.Values) (Envelope, int, error) {
+	var result Envelope
+	status, err := c.do(ctx, http.MethodGet, fmt.Sprintf("/car/%d/availability", id), query, nil, &result)
+	return result, status, err
+}
+
+func (c *CarClient) Rent(ctx context.Context, id int64, input RentInput) (Envelope, int, error) {
+	var result Envelope
+	status, err := c.do(ctx, http.MethodPost, fmt.Sprintf("/car/%d/rent", id), nil, input, &result)
+	return result, status, err
+}
+
+func (c *CarClient) Return(ctx context.Context, id int64) (Envelope, int, error) {
+	var result Envelope
+	status, err := c.do(ctx, http.MethodPut, fmt.Sprintf("/car/%d/return", id), nil, nil, &result)
+	return result, status, err
+}
+
+func (c *CarClient) CarRentals(ctx context.Context, id int64, query url.Values) (Envelope, int, error) {
+	var result Envelope
+	status, err := c.do(ctx, http.MethodGet, fmt.Sprintf("/car/%d/rentals", id), query, nil, &result)
+	return result, status, err
+}
+
+func (c *CarClient) UserRentals(ctx context.Context, userID int64, query url.Values) (Envelope, int, error) {
+	var result Envelope
+	status, err := c.do(ctx, http.MethodGet, fmt.Sprintf("/users/%d/rentals", userID), query, nil, &result)
+	return result, status, err
+}
+
+func (c *CarClient) Invoice(ctx context.Context, rentalID int64) (Envelope, int, error) {
+	var result Envelope
+	status, err := c.do(ctx, http.MethodGet, fmt.Sprintf("/rentals/%d/invoice", rentalID), nil, nil, &result)
+	return result, status, err
+}
@@ -0,0 +1,248 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"main_service/internal/jsonlog"
+	"main_service/internal/principal"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Envelope map[string]any
+
+// Decode converts the envelope into a typed value with the same JSON shape.
+func (e Envelope) Decode(dst any) error {
+	js, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(js, dst)
+}
+
+type Options struct {
+	Name            string
+	BaseURL         string
+	Timeout         time.Duration
+	Retry           RetryOptions
+	Breaker         BreakerOptions
+	Logger          *jsonlog.Logger
+	PrincipalSecret string
+}
+
+type contextKey string
+
+const (
+	bearerTokenContextKey = contextKey("bearerToken")
+	clientIPContextKey    = contextKey("clientIP")
+	principalContextKey   = contextKey("principal")
+)
+
+// withBearerToken makes requests sent with the returned context carry token in
+// their Authorization header.
+func withBearerToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, bearerTokenContextKey, token)
+}
+
+// WithClientIP makes requests sent with the returned context carry ip in their
+// X-Forwarded-For header, so that upstream services can tell clients apart.
+func WithClientIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, clientIPContextKey, ip)
+}
+
+// WithPrincipal makes requests sent with the returned context carry p in a
+// signed principal header, which is how upstream services learn who the end
+// user is.
+func WithPrincipal(ctx context.Context, p principal.Principal) context.Context {
+	return context.WithValue(ctx, principalContextKey, p)
+}
+
+type client struct {
+	name    string
+	baseURL string
+	timeout time.Duration
+	retry   RetryOptions
+	breaker *breaker
+	logger  *jsonlog.Logger
+	http    *http.Client
+	secret  []byte
+}
+
+func newClient(httpClient *http.Client, opts Options) client {
+	return client{
+		name:    opts.Name,
+		baseURL: strings.TrimSuffix(opts.BaseURL, "/"),
+		timeout: opts.Timeout,
+		retry:   opts.Retry,
+		breaker: newBreaker(opts.Name, opts.Breaker, opts.Logger),
+		logger:  opts.Logger,
+		http:    httpClient,
+		secret:  []byte(opts.PrincipalSecret),
+	}
+}
+
+// do sends body as JSON to the upstream service and decodes the response into
+// dst, which may be nil when the body is of no interest. Responses with a 4xx
+// or 5xx status are returned as an *UpstreamError, transport failures wrap
+// ErrUpstreamTimeout or ErrUpstreamUnavailable. Idempotent requests are
+// retried with jittered backoff on transport failures and 502/503/504.
+func (c client) do(ctx context.Context, method, path string, query url.Values, body any, dst any) (int, error) {
+	var js []byte
+	if body != nil {
+		var err error
+		js, err = json.Marshal(body)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return c.doRaw(ctx, method, path, query, "application/json", js, dst)
+}
+
+// doRaw behaves like do but sends body as is with the given content type.
+func (c client) doRaw(ctx context.Context, method, path string, query url.Values, contentType string, body []byte, dst any) (int, error) {
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	attempts := 1
+	if idempotent(method) && c.retry.MaxAttempts > 1 {
+		attempts = c.retry.MaxAttempts
+	}
+
+	var (
+		status int
+		err    error
+	)
+
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if attempt > 1 {
+			if c.logger != nil {
+				c.logger.PrintInfo("retrying upstream request", map[string]string{
+					"upstream": c.name,
+					"method":   method,
+					"url":      target,
+					"attempt":  strconv.Itoa(attempt),
+					"error":    err.Error(),
+				})
+			}
+
+			if sleep(ctx, c.retry.backoff(attempt-1)) != nil {
+				break
+			}
+		}
+
+		status, err = c.attempt(ctx, method, target, contentType, body, dst)
+		if !shouldRetry(status, err) || ctx.Err() != nil {
+			break
+		}
+	}
+
+	return status, err
+}
+
+func (c client) attempt(ctx context.Context, method, target, contentType string, body []byte, dst any) (int, error) {
+	err := c.breaker.allow()
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", err, c.name)
+	}
+
+	status, err := c.send(ctx, method, target, contentType, body, dst)
+
+	switch {
+	case errors.Is(err, context.Canceled) && ctx.Err() != nil:
+		c.breaker.release()
+	case errors.Is(err, ErrUpstreamTimeout), errors.Is(err, ErrUpstreamUnavailable), status >= http.StatusInternalServerError:
+		c.breaker.done(false)
+	default:
+		c.breaker.done(true)
+	}
+
+	return status, err
+}
+
+func (c client) send(ctx context.Context, method, target, contentType string, body []byte, dst any) (int, error) {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return 0, err
+	}
+
+	request.Header.Set("Content-Type", contentType)
+	if token, ok := ctx.Value(bearerTokenContextKey).(string); ok {
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+	if ip, ok := ctx.Value(clientIPContextKey).(string); ok {
+		request.Header.Set("X-Forwarded-For", ip)
+	}
+	if p, ok := ctx.Value(principalContextKey).(principal.Principal); ok && len(c.secret) > 0 {
+		value, err := principal.Sign(p, c.secret)
+		if err != nil {
+			return 0, err
+		}
+		request.Header.Set(principal.Header, value)
+	}
+
+	response, err := c.http.Do(request)
+	if err != nil {
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			return 0, fmt.Errorf("%w: %s %s: %v", ErrUpstreamTimeout, method, target, err)
+		case errors.Is(err, context.Canceled):
+			return 0, err
+		default:
+			return 0, fmt.Errorf("%w: %s %s: %v", ErrUpstreamUnavailable, method, target, err)
+		}
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= http.StatusBadRequest {
+		var payload struct {
+			Error any `json:"error"`
+		}
+		_ = json.NewDecoder(response.Body).Decode(&payload)
+
+		return response.StatusCode, newUpstreamError(response.StatusCode, payload.Error)
+	}
+
+	if dst == nil {
+		return response.StatusCode, nil
+	}
+
+	err = json.NewDecoder(response.Body).Decode(dst)
+	if err != nil {
+		return response.StatusCode, fmt.Errorf("%w: %s %s: %v", ErrInvalidResponse, method, target, err)
+	}
+
+	return response.StatusCode, nil
+}
+
+func shouldRetry(status int, err error) bool {
+	switch {
+	case err == nil:
+		return false
+	case errors.Is(err, ErrUpstreamTimeout), errors.Is(err, ErrUpstreamUnavailable):
+		return true
+	default:
+		return retryable(status)
+	}
+}
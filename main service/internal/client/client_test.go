@@ -0,0 +1,111 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBreaker(t *testing.T) {
+	now := time.Now()
+	b := newBreaker("test", BreakerOptions{Threshold: 2, Cooldown: time.Minute}, nil)
+	b.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		if err := b.allow(); err != nil {
+			t.Fatalf("call %d: expected closed breaker, got %v", i, err)
+		}
+		b.done(false)
+	}
+
+	if err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected open breaker, got %v", err)
+	}
+
+	now = now.Add(time.Minute)
+
+	if err := b.allow(); err != nil {
+		t.Fatalf("expected half-open probe to be allowed, got %v", err)
+	}
+	if err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected second half-open call to be rejected, got %v", err)
+	}
+
+	b.done(false)
+	if err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected failed probe to reopen breaker, got %v", err)
+	}
+
+	now = now.Add(time.Minute)
+
+	if err := b.allow(); err != nil {
+		t.Fatalf("expected half-open probe to be allowed, got %v", err)
+	}
+	b.done(true)
+
+	if b.state != stateClosed {
+		t.Fatalf("expected successful probe to close breaker, got %s", b.state)
+	}
+}
+
+func TestDoRetries(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		status   int
+		wantHits int32
+	}{
+		{"GET retries on 503", http.MethodGet, http.StatusServiceUnavailable, 3},
+		{"POST is not retried", http.MethodPost, http.StatusServiceUnavailable, 1},
+		{"GET is not retried on 422", http.MethodGet, http.StatusUnprocessableEntity, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var hits int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&hits, 1)
+				w.WriteHeader(tt.status)
+				w.Write([]byte(`{"error":"upstream failure"}`))
+			}))
+			defer srv.Close()
+
+			c := newClient(srv.Client(), Options{
+				Name:    "test",
+				BaseURL: srv.URL,
+				Timeout: time.Second,
+				Retry:   RetryOptions{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond},
+			})
+
+			_, err := c.do(context.Background(), tt.method, "/", nil, nil, nil)
+			if StatusOf(err) != tt.status {
+				t.Errorf("expected status %d, got %v", tt.status, err)
+			}
+			if hits != tt.wantHits {
+				t.Errorf("expected %d requests, got %d", tt.wantHits, hits)
+			}
+		})
+	}
+}
+
+func TestDoTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	c := newClient(srv.Client(), Options{
+		Name:    "test",
+		BaseURL: srv.URL,
+		Timeout: 10 * time.Millisecond,
+	})
+
+	_, err := c.do(context.Background(), http.MethodGet, "/", nil, nil, nil)
+	if !errors.Is(err, ErrUpstreamTimeout) {
+		t.Fatalf("expected timeout error, got %v", err)
+	}
+}
@@ -0,0 +1,44 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+var (
+	ErrUpstreamTimeout     = errors.New("upstream service timed out")
+	ErrUpstreamUnavailable = errors.New("upstream service unavailable")
+	ErrInvalidResponse     = errors.New("upstream service returned an invalid response")
+)
+
+// UpstreamError is returned when an upstream service answers with a 4xx or
+// 5xx status. Message holds the "error" value of the upstream envelope, which
+// is either a string or a map of validation errors.
+type UpstreamError struct {
+	Status  int
+	Message any
+}
+
+func (e *UpstreamError) Error() string {
+	return fmt.Sprintf("upstream responded with status %d: %v", e.Status, e.Message)
+}
+
+func newUpstreamError(status int, message any) *UpstreamError {
+	if message == nil {
+		message = http.StatusText(status)
+	}
+
+	return &UpstreamError{Status: status, Message: message}
+}
+
+// StatusOf reports the upstream status carried by err, or 0 if err did not
+// come from an upstream response.
+func StatusOf(err error) int {
+	var upstreamErr *UpstreamError
+	if errors.As(err, &upstreamErr) {
+		return upstreamErr.Status
+	}
+
+	return 0
+}
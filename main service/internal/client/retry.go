@@ -0,0 +1,61 @@
+package client
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+type RetryOptions struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// idempotent reports whether a request with the given method may be sent
+// again after a failure without changing the outcome.
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryable reports whether an upstream status is worth another attempt.
+func retryable(status int) bool {
+	switch status {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// backoff returns the delay before the given retry (1-based) using full
+// jitter: a random duration between zero and the capped exponential delay.
+func (o RetryOptions) backoff(retry int) time.Duration {
+	delay := o.BaseDelay << (retry - 1)
+	if delay <= 0 || delay > o.MaxDelay {
+		delay = o.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+
+	return time.Duration(rand.Int63n(int64(delay) + 1))
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
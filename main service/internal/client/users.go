@@ -0,0 +1,170 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"main_service/internal/models"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+var ErrInvalidToken = errors.New("invalid authentication token")
+
+type UserClient struct {
+	client
+}
+
+func NewUserClient(httpClient *http.Client, opts Options) *UserClient {
+	return &UserClient{client: newClient(httpClient, opts)}
+}
+
+type RegisterInput struct {
+	Name     string `json:"name"`
+	Surname  string `json:"surname"`
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+type TokenInput struct {
+	TokenPlaintext string `json:"token"`
+}
+
+type EmailInput struct {
+	Email string `json:"email"`
+}
+
+type PasswordResetInput struct {
+	Password       string `json:"password"`
+	TokenPlaintext string `json:"token"`
+}
+
+type RentalConfirmationInput struct {
+	CarID      int64     `json:"car_id"`
+	CarBrand   string    `json:"car_brand"`
+	Price      int32     `json:"price"`
+	TakingDate time.Time `json:"taking_date"`
+	ReturnDate time.Time `json:"return_date"`
+}
+
+type CredentialsInput struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+type RolesInput struct {
+	Roles []string `json:"roles"`
+}
+
+type RefreshInput struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+func (c *UserClient) Register(ctx context.Context, input RegisterInput) (Envelope, int, error) {
+	var result Envelope
+	status, err := c.do(ctx, http.MethodPost, "/users", nil, input, &result)
+	return result, status, err
+}
+
+func (c *UserClient) Activate(ctx context.Context, input TokenInput) (Envelope, int, error) {
+	var result Envelope
+	status, err := c.do(ctx, http.MethodPut, "/users/activated", nil, input, &result)
+	return result, status, err
+}
+
+func (c *UserClient) ResetPassword(ctx context.Context, input PasswordResetInput) (Envelope, int, error) {
+	var result Envelope
+	status, err := c.do(ctx, http.MethodPut, "/users/password", nil, input, &result)
+	return result, status, err
+}
+
+func (c *UserClient) Get(ctx context.Context, id int64) (Envelope, int, error) {
+	var result Envelope
+	status, err := c.do(ctx, http.MethodGet, fmt.Sprintf("/users/%d", id), nil, nil, &result)
+	return result, status, err
+}
+
+func (c *UserClient) Delete(ctx context.Context, id int64) (Envelope, int, error) {
+	var result Envelope
+	status, err := c.do(ctx, http.MethodDelete, fmt.Sprintf("/users/%d", id), nil, nil, &result)
+	return result, status, err
+}
+
+func (c *UserClient) SetRoles(ctx context.Context, id int64, input RolesInput) (Envelope, int, error) {
+	var result Envelope
+	status, err := c.do(ctx, http.MethodPut, fmt.Sprintf("/users/roles/%d", id), nil, input, &result)
+	return result, status, err
+}
+
+func (c *UserClient) Unlock(ctx context.Context, id int64) (Envelope, int, error) {
+	var result Envelope
+	status, err := c.do(ctx, http.MethodDelete, fmt.Sprintf("/users/%d/lockout", id), nil, nil, &result)
+	return result, status, err
+}
+
+func (c *UserClient) LoginAttempts(ctx context.Context, id int64, query url.Values) (Envelope, int, error) {
+	var result Envelope
+	status, err := c.do(ctx, http.MethodGet, fmt.Sprintf("/users/%d/login-attempts", id), query, nil, &result)
+	return result, status, err
+}
+
+func (c *UserClient) SendRentalConfirmation(ctx context.Context, userID int64, input RentalConfirmationInput) (Envelope, int, error) {
+	var result Envelope
+	status, err := c.do(ctx, http.MethodPost, fmt.Sprintf("/users/%d/rental-confirmation", userID), nil, input, &result)
+	return result, status, err
+}
+
+func (c *UserClient) CreateAuthenticationToken(ctx context.Context, input CredentialsInput) (Envelope, int, error) {
+	var result Envelope
+	status, err := c.do(ctx, http.MethodPost, "/tokens/authentication", nil, input, &result)
+	return result, status, err
+}
+
+func (c *UserClient) RefreshAuthenticationToken(ctx context.Context, input RefreshInput) (Envelope, int, error) {
+	var result Envelope
+	status, err := c.do(ctx, http.MethodPost, "/tokens/refresh", nil, input, &result)
+	return result, status, err
+}
+
+func (c *UserClient) CreatePasswordResetToken(ctx context.Context, input EmailInput) (Envelope, int, error) {
+	var result Envelope
+	status, err := c.do(ctx, http.MethodPost, "/tokens/password-reset", nil, input, &result)
+	return result, status, err
+}
+
+// Introspect resolves an authentication token to the user it belongs to.
+func (c *UserClient) Introspect(ctx context.Context, token string) (*models.User, error) {
+	var result struct {
+		User models.User `json:"user"`
+	}
+
+	_, err := c.do(ctx, http.MethodPost, "/tokens/introspect", nil, TokenInput{TokenPlaintext: token}, &result)
+	if err != nil {
+		if StatusOf(err) == http.StatusUnauthorized {
+			return nil, ErrInvalidToken
+		}
+		return nil, err
+	}
+
+	return &result.User, nil
+}
+
+// Sessions lists the active authentication tokens of the user owning token.
+func (c *UserClient) Sessions(ctx context.Context, token string) (Envelope, int, error) {
+	var result Envelope
+	status, err := c.do(withBearerToken(ctx, token), http.MethodGet, "/tokens/authentication", nil, nil, &result)
+	return result, status, err
+}
+
+func (c *UserClient) RevokeAuthenticationToken(ctx context.Context, token string) (Envelope, int, error) {
+	var result Envelope
+	status, err := c.do(withBearerToken(ctx, token), http.MethodDelete, "/tokens/authentication", nil, nil, &result)
+	return result, status, err
+}
+
+func (c *UserClient) RevokeAllAuthenticationTokens(ctx context.Context, token string) (Envelope, int, error) {
+	var result Envelope
+	status, err := c.do(withBearerToken(ctx, token), http.MethodDelete, "/tokens/authentication/all", nil, nil, &result)
+	return result, status, err
+}
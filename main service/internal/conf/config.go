@@ -0,0 +1,182 @@
+package conf
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Load fills the flags registered on fs. Values are taken, from lowest to
+// highest precedence, from the flag defaults, the YAML file named by -config
+// (or $CONFIG_FILE), environment variables and the command line arguments.
+//
+// The environment variable for a flag is its name in upper case with dashes
+// replaced by underscores, so -db-dsn is read from DB_DSN. Keys in the YAML
+// file are flag names; nested maps are joined with dashes, so
+//
+//	db:
+//	  dsn: postgres://...
+//
+// sets -db-dsn.
+func Load(fs *flag.FlagSet, args []string) error {
+	configFile := fs.String("config", os.Getenv("CONFIG_FILE"), "Path to a YAML configuration file")
+
+	err := fs.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	explicit := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) {
+		explicit[f.Name] = true
+	})
+
+	if *configFile != "" {
+		values, err := readFile(*configFile)
+		if err != nil {
+			return err
+		}
+
+		for _, name := range sortedKeys(values) {
+			if fs.Lookup(name) == nil {
+				return fmt.Errorf("config %s: unknown setting %q", *configFile, name)
+			}
+			if explicit[name] {
+				continue
+			}
+
+			err := fs.Set(name, values[name])
+			if err != nil {
+				return fmt.Errorf("config %s: invalid value for %q: %w", *configFile, name, err)
+			}
+		}
+	}
+
+	var envErr error
+	fs.VisitAll(func(f *flag.Flag) {
+		if envErr != nil || explicit[f.Name] || f.Name == "config" {
+			return
+		}
+
+		value, ok := os.LookupEnv(EnvName(f.Name))
+		if !ok {
+			return
+		}
+
+		err := fs.Set(f.Name, value)
+		if err != nil {
+			envErr = fmt.Errorf("invalid value for %s: %w", EnvName(f.Name), err)
+		}
+	})
+
+	return envErr
+}
+
+// EnvName returns the environment variable that sets the named flag.
+func EnvName(name string) string {
+	return strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+}
+
+// Require returns an error naming every flag in names that has an empty value.
+func Require(fs *flag.FlagSet, names ...string) error {
+	var missing []string
+
+	for _, name := range names {
+		f := fs.Lookup(name)
+		if f == nil || f.Value.String() == "" {
+			missing = append(missing, fmt.Sprintf("-%s (%s)", name, EnvName(name)))
+		}
+	}
+
+	if len(missing) > 0 {
+		return errors.New("missing required configuration: " + strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// Effective returns the value of every flag on fs for logging, with the values
+// of the named secrets redacted. URLs keep everything but their password.
+func Effective(fs *flag.FlagSet, secrets ...string) map[string]string {
+	secret := make(map[string]bool)
+	for _, name := range secrets {
+		secret[name] = true
+	}
+
+	values := make(map[string]string)
+	fs.VisitAll(func(f *flag.Flag) {
+		value := f.Value.String()
+		if secret[f.Name] {
+			value = redact(value)
+		}
+		values[f.Name] = value
+	})
+
+	return values
+}
+
+func redact(value string) string {
+	if value == "" {
+		return ""
+	}
+
+	u, err := url.Parse(value)
+	if err == nil && u.Scheme != "" && u.User != nil {
+		if _, ok := u.User.Password(); ok {
+			return u.Redacted()
+		}
+	}
+
+	return "[redacted]"
+}
+
+func readFile(path string) (map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw map[string]any
+	err = yaml.Unmarshal(content, &raw)
+	if err != nil {
+		return nil, fmt.Errorf("config %s: %w", path, err)
+	}
+
+	values := make(map[string]string)
+	flatten("", raw, values)
+
+	return values, nil
+}
+
+func flatten(prefix string, raw map[string]any, values map[string]string) {
+	for key, value := range raw {
+		name := key
+		if prefix != "" {
+			name = prefix + "-" + key
+		}
+
+		switch value := value.(type) {
+		case map[string]any:
+			flatten(name, value, values)
+		case nil:
+			values[name] = ""
+		default:
+			values[name] = fmt.Sprint(value)
+		}
+	}
+}
+
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -1,13 +1,37 @@
 package models
 
+const (
+	PermissionCarsWrite    = "cars:write"
+	PermissionCarsModerate = "cars:moderate"
+	PermissionRentalsRead  = "rentals:read"
+	PermissionUsersRead    = "users:read"
+	PermissionUsersAdmin   = "users:admin"
+)
+
 type User struct {
-	ID        int64  `json:"id"`
-	Name      string `json:"name"`
-	Surname   string `json:"surname"`
-	Email     string `json:"email"`
-	Password  string `json:"-"`
-	Activated bool   `json:"activated"`
-	Roles     string `json:"roles"`
+	ID          int64    `json:"id"`
+	Name        string   `json:"name"`
+	Surname     string   `json:"surname"`
+	Email       string   `json:"email"`
+	Password    string   `json:"-"`
+	Activated   bool     `json:"activated"`
+	Roles       []string `json:"roles"`
+	Permissions []string `json:"permissions"`
 }
 
 var AnonymousUser = &User{}
+
+func (u *User) IsAnonymous() bool {
+	return u == AnonymousUser
+}
+
+// HasPermission reports whether any of the user's roles grants the permission
+// code, as resolved by the user service when the token was introspected.
+func (u *User) HasPermission(code string) bool {
+	for _, permission := range u.Permissions {
+		if permission == code {
+			return true
+		}
+	}
+	return false
+}
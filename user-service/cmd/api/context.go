@@ -0,0 +1,23 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"user-service/internal/principal"
+)
+
+type contextKey string
+
+const principalContextKey = contextKey("principal")
+
+func (app *application) contextSetPrincipal(r *http.Request, p *principal.Principal) *http.Request {
+	ctx := context.WithValue(r.Context(), principalContextKey, p)
+	return r.WithContext(ctx)
+}
+
+// contextGetPrincipal returns the verified caller, or nil for requests that
+// didn't carry one.
+func (app *application) contextGetPrincipal(r *http.Request) *principal.Principal {
+	p, _ := r.Context().Value(principalContextKey).(*principal.Principal)
+	return p
+}
@@ -10,6 +10,8 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"user-service/internal/data"
+	"user-service/internal/models"
 	"user-service/internal/validator"
 )
 
@@ -121,6 +123,30 @@ func (app *application) readInt(qs url.Values, key string, defaultValue int, v *
 	return i
 }
 
+// userForBearerToken resolves the authentication token in the request's
+// "Authorization: Bearer <token>" header. A missing, malformed or unknown token
+// is reported as models.ErrRecordNotFound.
+func (app *application) userForBearerToken(r *http.Request) (*models.User, string, error) {
+	headerParts := strings.Split(r.Header.Get("Authorization"), " ")
+	if len(headerParts) != 2 || headerParts[0] != "Bearer" {
+		return nil, "", models.ErrRecordNotFound
+	}
+
+	token := headerParts[1]
+
+	v := validator.New()
+	if data.ValidateTokenPlaintext(v, token); !v.Valid() {
+		return nil, "", models.ErrRecordNotFound
+	}
+
+	user, err := app.models.Users.GetForToken(data.ScopeAuthentication, token)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return user, token, nil
+}
+
 func (app *application) background(fn func()) {
 	app.wg.Add(1)
 
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"net/http"
+	"time"
+	"user-service/internal/models"
+)
+
+// recordLoginAttempt adds the attempt to the login audit log. A failure to do
+// so is logged but doesn't affect the login itself.
+func (app *application) recordLoginAttempt(r *http.Request, attempt *models.LoginAttempt) {
+	err := app.models.LoginAttempts.Insert(attempt)
+	if err != nil {
+		app.logError(r, err)
+	}
+}
+
+// delayFailedLogin holds back the response to a failed login, doubling the
+// delay with every consecutive failure up to the configured maximum.
+func (app *application) delayFailedLogin(r *http.Request, failures int) {
+	delay := app.config.lockout.delay
+	if delay <= 0 || failures < 1 {
+		return
+	}
+
+	for i := 1; i < failures && delay < app.config.lockout.maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > app.config.lockout.maxDelay {
+		delay = app.config.lockout.maxDelay
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+	case <-r.Context().Done():
+	}
+}
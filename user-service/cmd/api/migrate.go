@@ -0,0 +1,77 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+	"user-service/internal/jsonlog"
+	"user-service/internal/migrate"
+	"user-service/migrations"
+)
+
+const migrationsTable = "user_service_schema_migrations"
+
+func runMigrations(db *sql.DB, logger *jsonlog.Logger, command string) error {
+	migrator := migrate.Migrator{
+		DB:    db,
+		FS:    migrations.FS,
+		Table: migrationsTable,
+	}
+
+	switch command {
+	case "up":
+		applied, err := migrator.Up()
+		for _, m := range applied {
+			logger.PrintInfo("migration applied", map[string]string{
+				"version": strconv.FormatInt(m.Version, 10),
+				"name":    m.Name,
+			})
+		}
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			logger.PrintInfo("no pending migrations", nil)
+		}
+
+	case "down":
+		m, err := migrator.Down()
+		if err != nil {
+			switch {
+			case errors.Is(err, migrate.ErrNoMigrations):
+				logger.PrintInfo("no migrations to roll back", nil)
+				return nil
+			default:
+				return err
+			}
+		}
+		logger.PrintInfo("migration rolled back", map[string]string{
+			"version": strconv.FormatInt(m.Version, 10),
+			"name":    m.Name,
+		})
+
+	case "status":
+		statuses, err := migrator.Status()
+		if err != nil {
+			return err
+		}
+		for _, s := range statuses {
+			appliedAt := "pending"
+			if s.AppliedAt != nil {
+				appliedAt = s.AppliedAt.Format(time.RFC3339)
+			}
+			logger.PrintInfo("migration status", map[string]string{
+				"version":    strconv.FormatInt(s.Version, 10),
+				"name":       s.Name,
+				"applied_at": appliedAt,
+			})
+		}
+
+	default:
+		return fmt.Errorf("unknown migrate command %q (expected up, down or status)", command)
+	}
+
+	return nil
+}
@@ -3,9 +3,10 @@ package main
 import (
 	"github.com/julienschmidt/httprouter"
 	"net/http"
+	"user-service/internal/models"
 )
 
-func (app *application) routes() *httprouter.Router {
+func (app *application) routes() http.Handler {
 	router := httprouter.New()
 
 	router.NotFound = http.HandlerFunc(app.notFoundResponse)
@@ -13,10 +14,23 @@ func (app *application) routes() *httprouter.Router {
 
 	router.HandlerFunc(http.MethodPost, "/users", app.registerUserHandler)
 	router.HandlerFunc(http.MethodPut, "/users/activated", app.activateUserHandler)
-	router.HandlerFunc(http.MethodGet, "/users/:id", app.showUserHandler)
-	router.HandlerFunc(http.MethodDelete, "/users/:id", app.deleteUserHandler)
+	router.HandlerFunc(http.MethodPut, "/users/password", app.updateUserPasswordHandler)
+	router.HandlerFunc(http.MethodGet, "/users/:id", app.requirePermission(models.PermissionUsersRead, app.showUserHandler))
+	router.HandlerFunc(http.MethodDelete, "/users/:id", app.requirePermission(models.PermissionUsersAdmin, app.deleteUserHandler))
+	// Not /users/:id/roles, which httprouter can't register next to the
+	// static PUT /users/activated and /users/password routes.
+	router.HandlerFunc(http.MethodPut, "/users/roles/:id", app.requirePermission(models.PermissionUsersAdmin, app.setRolesHandler))
+	router.HandlerFunc(http.MethodPost, "/users/:id/rental-confirmation", app.sendRentalConfirmationHandler)
+	router.HandlerFunc(http.MethodDelete, "/users/:id/lockout", app.requirePermission(models.PermissionUsersAdmin, app.unlockUserHandler))
+	router.HandlerFunc(http.MethodGet, "/users/:id/login-attempts", app.requirePermission(models.PermissionUsersAdmin, app.listLoginAttemptsHandler))
 
 	router.HandlerFunc(http.MethodPost, "/tokens/authentication", app.createAuthenticationTokenHandler)
+	router.HandlerFunc(http.MethodGet, "/tokens/authentication", app.listAuthenticationTokensHandler)
+	router.HandlerFunc(http.MethodDelete, "/tokens/authentication", app.revokeAuthenticationTokenHandler)
+	router.HandlerFunc(http.MethodDelete, "/tokens/authentication/all", app.revokeAllAuthenticationTokensHandler)
+	router.HandlerFunc(http.MethodPost, "/tokens/refresh", app.refreshAuthenticationTokenHandler)
+	router.HandlerFunc(http.MethodPost, "/tokens/introspect", app.introspectAuthenticationTokenHandler)
+	router.HandlerFunc(http.MethodPost, "/tokens/password-reset", app.createPasswordResetTokenHandler)
 
-	return router
+	return app.rateLimit(app.authenticate(router))
 }
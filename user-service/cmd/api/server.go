@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 	"time"
 )
@@ -22,6 +23,15 @@ func (app *application) serve() error {
 
 	shutdownError := make(chan error)
 
+	ctx, stopSweeper := context.WithCancel(context.Background())
+	defer stopSweeper()
+
+	if app.config.tokens.sweepInterval > 0 {
+		app.background(func() {
+			app.sweepExpiredTokens(ctx, app.config.tokens.sweepInterval)
+		})
+	}
+
 	go func() {
 		quit := make(chan os.Signal, 1)
 		signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -39,6 +49,8 @@ func (app *application) serve() error {
 			shutdownError <- err
 		}
 
+		stopSweeper()
+
 		app.logger.PrintInfo("completing background tasks", map[string]string{
 			"addr": srv.Addr,
 		})
@@ -68,3 +80,29 @@ func (app *application) serve() error {
 
 	return nil
 }
+
+// sweepExpiredTokens deletes expired tokens every interval until ctx is
+// cancelled.
+func (app *application) sweepExpiredTokens(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deleted, err := app.models.Tokens.DeleteExpired()
+			if err != nil {
+				app.logger.PrintError(err, nil)
+				continue
+			}
+
+			if deleted > 0 {
+				app.logger.PrintInfo("expired tokens deleted", map[string]string{
+					"count": strconv.FormatInt(deleted, 10),
+				})
+			}
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 	"user-service/internal/data"
 	"user-service/internal/models"
+	"user-service/internal/ratelimit"
 	"user-service/internal/validator"
 )
 
@@ -29,10 +30,27 @@ func (app *application) createAuthenticationTokenHandler(w http.ResponseWriter,
 		return
 	}
 
+	ip := ratelimit.ClientIP(r, app.config.limiter.trustedProxies)
+
+	ipFailures, err := app.models.LoginAttempts.CountFailuresForIP(ip, time.Now().Add(-app.config.lockout.ipWindow))
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	if app.config.lockout.ipMaxFailures > 0 && ipFailures >= app.config.lockout.ipMaxFailures {
+		app.tooManyLoginAttemptsResponse(w, r)
+		return
+	}
+
+	attempt := &models.LoginAttempt{Email: input.Email, IP: ip}
+
 	user, err := app.models.Users.GetByEmail(input.Email)
 	if err != nil {
 		switch {
 		case errors.Is(err, models.ErrRecordNotFound):
+			app.recordLoginAttempt(r, attempt)
+			app.delayFailedLogin(r, ipFailures+1)
 			app.invalidCredentialsResponse(w, r)
 		default:
 			app.serverErrorResponse(w, r, err)
@@ -40,6 +58,17 @@ func (app *application) createAuthenticationTokenHandler(w http.ResponseWriter,
 		return
 	}
 
+	attempt.UserID = &user.ID
+
+	// A locked account gets the same response as an unknown email, so the
+	// lockout doesn't reveal which accounts exist.
+	if user.IsLocked() {
+		app.recordLoginAttempt(r, attempt)
+		app.delayFailedLogin(r, user.FailedLogins)
+		app.invalidCredentialsResponse(w, r)
+		return
+	}
+
 	match, err := user.Password.Matches(input.Password)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
@@ -47,17 +76,253 @@ func (app *application) createAuthenticationTokenHandler(w http.ResponseWriter,
 	}
 
 	if !match {
+		app.recordLoginAttempt(r, attempt)
+
+		user, err = app.models.Users.RecordFailedLogin(user.ID, app.config.lockout.maxFailures, app.config.lockout.duration)
+		if err != nil {
+			app.serverErrorResponse(w, r, err)
+			return
+		}
+
+		app.delayFailedLogin(r, user.FailedLogins)
 		app.invalidCredentialsResponse(w, r)
 		return
 	}
 
-	token, err := app.models.Tokens.New(user.ID, 24*time.Hour, data.ScopeAuthentication)
+	attempt.Succeeded = true
+	app.recordLoginAttempt(r, attempt)
+
+	if user.FailedLogins > 0 || user.LockedUntil != nil {
+		err = app.models.Users.ResetFailedLogins(user.ID)
+		if err != nil {
+			app.serverErrorResponse(w, r, err)
+			return
+		}
+	}
+
+	access, refresh, err := app.models.Tokens.NewPair(user.ID, app.config.tokens.accessTTL, app.config.tokens.refreshTTL)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusCreated, envelope{"authentication_token": access, "refresh_token": refresh}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) refreshAuthenticationTokenHandler(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		RefreshToken string `json:"refresh_token"`
+	}
+
+	err := app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	if data.ValidateTokenPlaintext(v, input.RefreshToken); !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	access, refresh, err := app.models.Tokens.Rotate(input.RefreshToken, app.config.tokens.accessTTL, app.config.tokens.refreshTTL)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrTokenReused):
+			app.logger.PrintInfo("refresh token reused, token family revoked", map[string]string{
+				"remote_addr": r.RemoteAddr,
+			})
+			app.invalidRefreshTokenResponse(w, r)
+		case errors.Is(err, data.ErrInvalidToken):
+			app.invalidRefreshTokenResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusCreated, envelope{"authentication_token": access, "refresh_token": refresh}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) introspectAuthenticationTokenHandler(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		TokenPlaintext string `json:"token"`
+	}
+
+	err := app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	if data.ValidateTokenPlaintext(v, input.TokenPlaintext); !v.Valid() {
+		app.invalidAuthenticationTokenResponse(w, r)
+		return
+	}
+
+	user, err := app.models.Users.GetForToken(data.ScopeAuthentication, input.TokenPlaintext)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrRecordNotFound):
+			app.invalidAuthenticationTokenResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	err = app.models.Tokens.Touch(input.TokenPlaintext)
+	if err != nil {
+		app.logError(r, err)
+	}
+
+	user.Permissions, err = app.models.Permissions.GetAllForRoles(user.Roles)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"user": user}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) listAuthenticationTokensHandler(w http.ResponseWriter, r *http.Request) {
+	user, token, err := app.userForBearerToken(r)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrRecordNotFound):
+			app.invalidAuthenticationTokenResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	sessions, err := app.models.Tokens.GetSessions(user.ID, token)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"sessions": sessions}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) revokeAuthenticationTokenHandler(w http.ResponseWriter, r *http.Request) {
+	_, token, err := app.userForBearerToken(r)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrRecordNotFound):
+			app.invalidAuthenticationTokenResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	err = app.models.Tokens.RevokeFamily(token)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"message": "authentication token revoked"}, nil)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) revokeAllAuthenticationTokensHandler(w http.ResponseWriter, r *http.Request) {
+	user, _, err := app.userForBearerToken(r)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrRecordNotFound):
+			app.invalidAuthenticationTokenResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
 		return
 	}
 
-	err = app.writeJSON(w, http.StatusCreated, envelope{"authentication_token": token}, nil)
+	for _, scope := range []string{data.ScopeAuthentication, data.ScopeRefresh} {
+		err = app.models.Tokens.DeleteAllForUser(scope, user.ID)
+		if err != nil {
+			app.serverErrorResponse(w, r, err)
+			return
+		}
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"message": "all authentication tokens revoked"}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) createPasswordResetTokenHandler(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Email string `json:"email"`
+	}
+
+	err := app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	if models.ValidateEmail(v, input.Email); !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	// The lookup runs in the background and the response is the same whether
+	// or not the address belongs to an account, so it can't be used to probe
+	// for registered emails.
+	app.background(func() {
+		user, err := app.models.Users.GetByEmail(input.Email)
+		if err != nil {
+			if !errors.Is(err, models.ErrRecordNotFound) {
+				app.logger.PrintError(err, nil)
+			}
+			return
+		}
+
+		if !user.Activated {
+			return
+		}
+
+		token, err := app.models.Tokens.New(user.ID, 45*time.Minute, data.ScopePasswordReset)
+		if err != nil {
+			app.logger.PrintError(err, nil)
+			return
+		}
+
+		data := map[string]any{
+			"passwordResetToken": token.Plaintext,
+			"userName":           user.Name,
+		}
+
+		err = app.mailer.Send(user.Email, "password_reset.tmpl", data)
+		if err != nil {
+			app.logger.PrintError(err, nil)
+		}
+	})
+
+	env := envelope{"message": "if the email address belongs to an activated account you will receive password reset instructions"}
+
+	err = app.writeJSON(w, http.StatusAccepted, env, nil)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
 	}
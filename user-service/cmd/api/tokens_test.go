@@ -0,0 +1,435 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"user-service/internal/data"
+	"user-service/internal/jsonlog"
+	"user-service/internal/mailer"
+	"user-service/internal/models"
+	"user-service/internal/principal"
+	"user-service/internal/ratelimit"
+)
+
+func newTestApplication(t *testing.T) *application {
+	app := &application{
+		logger: jsonlog.New(io.Discard, jsonlog.LevelInfo),
+		models: models.NewMemoryModels(),
+		mailer: mailer.New(mailer.NewWriterTransport(io.Discard), "test@miracle.kz"),
+	}
+	app.config.principal.secret = "test-secret"
+	app.config.tokens.accessTTL = 15 * time.Minute
+	app.config.tokens.refreshTTL = 24 * time.Hour
+
+	user := &models.User{
+		Name:      "Aldi",
+		Surname:   "Test",
+		Email:     "aldi@example.com",
+		Activated: true,
+		Roles:     []string{models.RoleDefault},
+	}
+
+	err := user.Password.Set("pa55word123")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = app.models.Users.Insert(user)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return app
+}
+
+// setPrincipal signs the user into the request the way the gateway does.
+func setPrincipal(t *testing.T, app *application, r *http.Request, userID int64, permissions ...string) {
+	value, err := principal.Sign(principal.Principal{UserID: userID, Permissions: permissions}, []byte(app.config.principal.secret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Header.Set(principal.Header, value)
+}
+
+func TestAuthenticationTokenHandlers(t *testing.T) {
+	app := newTestApplication(t)
+	router := app.routes()
+
+	testTable := []struct {
+		name       string
+		body       string
+		httpStatus int
+	}{
+		{
+			name:       "Valid credentials",
+			body:       `{"email": "aldi@example.com", "password": "pa55word123"}`,
+			httpStatus: http.StatusCreated,
+		},
+		{
+			name:       "Wrong password",
+			body:       `{"email": "aldi@example.com", "password": "wrongpassword"}`,
+			httpStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "Unknown email",
+			body:       `{"email": "nobody@example.com", "password": "pa55word123"}`,
+			httpStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "Invalid email",
+			body:       `{"email": "nobody", "password": "pa55word123"}`,
+			httpStatus: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, testTable := range testTable {
+		t.Run(testTable.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/tokens/authentication", strings.NewReader(testTable.body))
+			rr := httptest.NewRecorder()
+
+			router.ServeHTTP(rr, req)
+
+			if status := rr.Code; status != testTable.httpStatus {
+				t.Fatalf("handler returned wrong status code: got %v want %v", status, testTable.httpStatus)
+			}
+
+			if rr.Code != http.StatusCreated {
+				return
+			}
+
+			var body struct {
+				Token struct {
+					Plaintext string `json:"token"`
+				} `json:"authentication_token"`
+			}
+			err := json.NewDecoder(rr.Body).Decode(&body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req = httptest.NewRequest(http.MethodPost, "/tokens/introspect", strings.NewReader(`{"token": "`+body.Token.Plaintext+`"}`))
+			rr = httptest.NewRecorder()
+
+			router.ServeHTTP(rr, req)
+
+			if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), "aldi@example.com") {
+				t.Errorf("introspection failed: %d %s", rr.Code, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestRevokeAuthenticationTokenHandlers(t *testing.T) {
+	app := newTestApplication(t)
+	router := app.routes()
+
+	login := func() string {
+		req := httptest.NewRequest(http.MethodPost, "/tokens/authentication", strings.NewReader(`{"email": "aldi@example.com", "password": "pa55word123"}`))
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		var body struct {
+			Token struct {
+				Plaintext string `json:"token"`
+			} `json:"authentication_token"`
+		}
+		err := json.NewDecoder(rr.Body).Decode(&body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return body.Token.Plaintext
+	}
+
+	send := func(method, url, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, url, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	introspect := func(token string) int {
+		req := httptest.NewRequest(http.MethodPost, "/tokens/introspect", strings.NewReader(`{"token": "`+token+`"}`))
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr.Code
+	}
+
+	first, second, third := login(), login(), login()
+
+	rr := send(http.MethodGet, "/tokens/authentication", first)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("listing sessions failed: %d %s", rr.Code, rr.Body.String())
+	}
+
+	var body struct {
+		Sessions []struct {
+			Current bool `json:"current"`
+		} `json:"sessions"`
+	}
+	err := json.NewDecoder(rr.Body).Decode(&body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	current := 0
+	for _, session := range body.Sessions {
+		if session.Current {
+			current++
+		}
+	}
+	if len(body.Sessions) != 3 || current != 1 {
+		t.Fatalf("expected 3 sessions with one current, got %+v", body.Sessions)
+	}
+
+	if rr := send(http.MethodDelete, "/tokens/authentication", ""); rr.Code != http.StatusUnauthorized {
+		t.Errorf("revoking without a token: got %d", rr.Code)
+	}
+
+	if rr := send(http.MethodDelete, "/tokens/authentication", first); rr.Code != http.StatusOK {
+		t.Fatalf("revoking the current token failed: %d %s", rr.Code, rr.Body.String())
+	}
+
+	if code := introspect(first); code != http.StatusUnauthorized {
+		t.Errorf("revoked token still valid: %d", code)
+	}
+	if code := introspect(second); code != http.StatusOK {
+		t.Errorf("other token revoked too: %d", code)
+	}
+
+	if rr := send(http.MethodDelete, "/tokens/authentication/all", second); rr.Code != http.StatusOK {
+		t.Fatalf("revoking all tokens failed: %d %s", rr.Code, rr.Body.String())
+	}
+
+	if code := introspect(third); code != http.StatusUnauthorized {
+		t.Errorf("token survived logout everywhere: %d", code)
+	}
+}
+
+func TestRefreshAuthenticationTokenHandler(t *testing.T) {
+	app := newTestApplication(t)
+	router := app.routes()
+
+	type pair struct {
+		Access struct {
+			Plaintext string `json:"token"`
+		} `json:"authentication_token"`
+		Refresh struct {
+			Plaintext string `json:"token"`
+		} `json:"refresh_token"`
+	}
+
+	post := func(url, body string) (*httptest.ResponseRecorder, pair) {
+		req := httptest.NewRequest(http.MethodPost, url, strings.NewReader(body))
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		var tokens pair
+		if rr.Code == http.StatusCreated {
+			err := json.Unmarshal(rr.Body.Bytes(), &tokens)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		return rr, tokens
+	}
+
+	refresh := func(token string) (*httptest.ResponseRecorder, pair) {
+		return post("/tokens/refresh", `{"refresh_token": "`+token+`"}`)
+	}
+
+	introspect := func(token string) int {
+		rr, _ := post("/tokens/introspect", `{"token": "`+token+`"}`)
+		return rr.Code
+	}
+
+	_, login := post("/tokens/authentication", `{"email": "aldi@example.com", "password": "pa55word123"}`)
+	if login.Refresh.Plaintext == "" {
+		t.Fatal("login did not return a refresh token")
+	}
+
+	if code := introspect(login.Refresh.Plaintext); code != http.StatusUnauthorized {
+		t.Errorf("refresh token accepted as an access token: %d", code)
+	}
+
+	rr, rotated := refresh(login.Refresh.Plaintext)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("refresh failed: %d %s", rr.Code, rr.Body.String())
+	}
+
+	if code := introspect(login.Access.Plaintext); code != http.StatusUnauthorized {
+		t.Errorf("access token survived rotation: %d", code)
+	}
+	if code := introspect(rotated.Access.Plaintext); code != http.StatusOK {
+		t.Errorf("rotated access token rejected: %d", code)
+	}
+
+	if rr, _ := refresh(login.Refresh.Plaintext); rr.Code != http.StatusUnauthorized {
+		t.Fatalf("reused refresh token: got %d", rr.Code)
+	}
+
+	if code := introspect(rotated.Access.Plaintext); code != http.StatusUnauthorized {
+		t.Errorf("family not revoked after reuse: %d", code)
+	}
+	if rr, _ := refresh(rotated.Refresh.Plaintext); rr.Code != http.StatusUnauthorized {
+		t.Errorf("rotated refresh token survived reuse: %d", rr.Code)
+	}
+}
+
+func TestAuthenticationRateLimit(t *testing.T) {
+	app := newTestApplication(t)
+	app.limiter = ratelimit.New()
+	app.config.limiter.routes = ratelimit.Routes{
+		{Method: http.MethodPost, Pattern: "/tokens/authentication", Limit: ratelimit.Limit{RPS: 0.01, Burst: 2}},
+	}
+	router := app.routes()
+
+	login := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/tokens/authentication", strings.NewReader(`{"email": "aldi@example.com", "password": "wrongpassword"}`))
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	for i := 0; i < 2; i++ {
+		if rr := login(); rr.Code != http.StatusUnauthorized {
+			t.Fatalf("attempt %d: got %d", i+1, rr.Code)
+		}
+	}
+
+	rr := login()
+	if rr.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected %d, got %d", http.StatusTooManyRequests, rr.Code)
+	}
+	if rr.Header().Get("Retry-After") == "" || rr.Header().Get("RateLimit-Remaining") != "0" {
+		t.Errorf("missing rate limit headers: %v", rr.Header())
+	}
+}
+
+func TestAccountLockout(t *testing.T) {
+	app := newTestApplication(t)
+	app.config.lockout.maxFailures = 3
+	app.config.lockout.duration = time.Hour
+	router := app.routes()
+
+	loginAs := func(email, password string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/tokens/authentication", strings.NewReader(`{"email": "`+email+`", "password": "`+password+`"}`))
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+	login := func(password string) int {
+		return loginAs("aldi@example.com", password).Code
+	}
+
+	for i := 0; i < 3; i++ {
+		if code := login("wrongpassword"); code != http.StatusUnauthorized {
+			t.Fatalf("attempt %d: got %d", i+1, code)
+		}
+	}
+
+	locked := loginAs("aldi@example.com", "pa55word123")
+	if locked.Code != http.StatusUnauthorized {
+		t.Fatalf("locked account accepted the right password: %d", locked.Code)
+	}
+
+	unknown := loginAs("nobody@example.com", "pa55word123")
+	if locked.Body.String() != unknown.Body.String() {
+		t.Errorf("locked account is distinguishable from an unknown one: %q vs %q", locked.Body.String(), unknown.Body.String())
+	}
+
+	req := httptest.NewRequest(http.MethodDelete, "/users/1/lockout", nil)
+	setPrincipal(t, app, req, 2, models.PermissionUsersAdmin)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("unlock failed: %d %s", rr.Code, rr.Body.String())
+	}
+
+	if code := login("pa55word123"); code != http.StatusCreated {
+		t.Fatalf("login after unlock: got %d", code)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/users/1/login-attempts", nil)
+	setPrincipal(t, app, req, 2, models.PermissionUsersAdmin)
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	var body struct {
+		Attempts []models.LoginAttempt `json:"login_attempts"`
+	}
+	err := json.NewDecoder(rr.Body).Decode(&body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(body.Attempts) != 5 || !body.Attempts[0].Succeeded {
+		t.Errorf("unexpected audit log: %+v", body.Attempts)
+	}
+}
+
+func TestSetRolesHandler(t *testing.T) {
+	app := newTestApplication(t)
+	router := app.routes()
+
+	setRolesAs := func(permissions []string, body string) int {
+		req := httptest.NewRequest(http.MethodPut, "/users/roles/1", strings.NewReader(body))
+		if permissions != nil {
+			setPrincipal(t, app, req, 1, permissions...)
+		}
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr.Code
+	}
+	setRoles := func(body string) int {
+		return setRolesAs([]string{models.PermissionUsersAdmin}, body)
+	}
+
+	if code := setRolesAs(nil, `{"roles": ["ADMIN"]}`); code != http.StatusUnauthorized {
+		t.Errorf("without a principal: got %d", code)
+	}
+	if code := setRolesAs([]string{models.PermissionCarsWrite}, `{"roles": ["ADMIN"]}`); code != http.StatusForbidden {
+		t.Errorf("without users:admin: got %d", code)
+	}
+
+	if code := setRoles(`{"roles": ["SUPERUSER"]}`); code != http.StatusUnprocessableEntity {
+		t.Errorf("unknown role: got %d", code)
+	}
+	if code := setRoles(`{"roles": []}`); code != http.StatusUnprocessableEntity {
+		t.Errorf("no roles: got %d", code)
+	}
+	if code := setRoles(`{"roles": ["MODERATOR"]}`); code != http.StatusOK {
+		t.Fatalf("setting roles failed: %d", code)
+	}
+
+	token, err := app.models.Tokens.New(1, time.Hour, data.ScopeAuthentication)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/tokens/introspect", strings.NewReader(`{"token": "`+token.Plaintext+`"}`))
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	var body struct {
+		User struct {
+			Roles       []string           `json:"roles"`
+			Permissions models.Permissions `json:"permissions"`
+		} `json:"user"`
+	}
+	err = json.NewDecoder(rr.Body).Decode(&body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !body.User.Permissions.Include(models.PermissionCarsModerate) || body.User.Permissions.Include(models.PermissionUsersAdmin) {
+		t.Errorf("unexpected permissions for a moderator: %v", body.User.Permissions)
+	}
+}
@@ -28,7 +28,7 @@ func (app *application) registerUserHandler(w http.ResponseWriter, r *http.Reque
 		Surname:   input.Surname,
 		Email:     input.Email,
 		Activated: false,
-		Roles:     "DEFAULT",
+		Roles:     []string{models.RoleDefault},
 	}
 
 	err = user.Password.Set(input.Password)
@@ -62,12 +62,20 @@ func (app *application) registerUserHandler(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	data := map[string]any{
-		"activationToken": token.Plaintext,
-		"userName":        user.Name,
-	}
+	app.background(func() {
+		data := map[string]any{
+			"activationToken": token.Plaintext,
+			"userName":        user.Name,
+			"userID":          user.ID,
+		}
+
+		err := app.mailer.Send(user.Email, "user_welcome.tmpl", data)
+		if err != nil {
+			app.logger.PrintError(err, nil)
+		}
+	})
 
-	err = app.writeJSON(w, http.StatusAccepted, envelope{"user": user, "token": data}, nil)
+	err = app.writeJSON(w, http.StatusAccepted, envelope{"user": user}, nil)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
 	}
@@ -122,6 +130,148 @@ func (app *application) activateUserHandler(w http.ResponseWriter, r *http.Reque
 	}
 }
 
+func (app *application) updateUserPasswordHandler(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Password       string `json:"password"`
+		TokenPlaintext string `json:"token"`
+	}
+
+	err := app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+
+	models.ValidatePasswordPlaintext(v, input.Password)
+	data.ValidateTokenPlaintext(v, input.TokenPlaintext)
+
+	if !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	user, err := app.models.Users.GetForToken(data.ScopePasswordReset, input.TokenPlaintext)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrRecordNotFound):
+			v.AddError("token", "invalid or expired password reset token")
+			app.failedValidationResponse(w, r, v.Errors)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	err = user.Password.Set(input.Password)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.models.Users.Update(user)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.models.Tokens.DeleteAllForUser(data.ScopePasswordReset, user.ID)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.models.Tokens.DeleteAllForUser(data.ScopeAuthentication, user.ID)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.models.Tokens.DeleteAllForUser(data.ScopeRefresh, user.ID)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.models.Users.ResetFailedLogins(user.ID)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"message": "your password was successfully reset"}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) sendRentalConfirmationHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := app.readIDParam(r)
+	if err != nil {
+		app.notFoundResponse(w, r)
+		return
+	}
+
+	var input struct {
+		CarID      int64     `json:"car_id"`
+		CarBrand   string    `json:"car_brand"`
+		Price      int32     `json:"price"`
+		TakingDate time.Time `json:"taking_date"`
+		ReturnDate time.Time `json:"return_date"`
+	}
+
+	err = app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+
+	v.Check(input.CarID > 0, "car_id", "must be provided")
+	v.Check(input.CarBrand != "", "car_brand", "must be provided")
+	v.Check(!input.TakingDate.IsZero(), "taking_date", "must be provided")
+	v.Check(input.ReturnDate.After(input.TakingDate), "return_date", "must be after taking_date")
+
+	if !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	user, err := app.models.Users.GetById(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	app.background(func() {
+		data := map[string]any{
+			"userName":   user.Name,
+			"carID":      input.CarID,
+			"carBrand":   input.CarBrand,
+			"price":      input.Price,
+			"takingDate": input.TakingDate.Format(time.RFC1123),
+			"returnDate": input.ReturnDate.Format(time.RFC1123),
+		}
+
+		err := app.mailer.Send(user.Email, "rental_confirmation.tmpl", data)
+		if err != nil {
+			app.logger.PrintError(err, nil)
+		}
+	})
+
+	err = app.writeJSON(w, http.StatusAccepted, envelope{"message": "rental confirmation will be sent to the user"}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
 func (app *application) deleteUserHandler(w http.ResponseWriter, r *http.Request) {
 	id, err := app.readIDParam(r)
 	if err != nil {
@@ -146,7 +296,7 @@ func (app *application) deleteUserHandler(w http.ResponseWriter, r *http.Request
 	}
 }
 
-func (app *application) setRoleHandler(w http.ResponseWriter, r *http.Request) {
+func (app *application) setRolesHandler(w http.ResponseWriter, r *http.Request) {
 	id, err := app.readIDParam(r)
 	if err != nil {
 		app.notFoundResponse(w, r)
@@ -165,7 +315,7 @@ func (app *application) setRoleHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var input struct {
-		Role string `json:"role"`
+		Roles []string `json:"roles"`
 	}
 
 	err = app.readJSON(w, r, &input)
@@ -174,7 +324,13 @@ func (app *application) setRoleHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user.Roles = input.Role
+	v := validator.New()
+	if models.ValidateRoles(v, input.Roles); !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	user.Roles = input.Roles
 
 	err = app.models.Users.Update(user)
 	if err != nil {
@@ -211,3 +367,66 @@ func (app *application) showUserHandler(w http.ResponseWriter, r *http.Request)
 		app.serverErrorResponse(w, r, err)
 	}
 }
+
+func (app *application) unlockUserHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := app.readIDParam(r)
+	if err != nil {
+		app.notFoundResponse(w, r)
+		return
+	}
+
+	err = app.models.Users.ResetFailedLogins(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"message": "user account successfully unlocked"}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) listLoginAttemptsHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := app.readIDParam(r)
+	if err != nil {
+		app.notFoundResponse(w, r)
+		return
+	}
+
+	v := validator.New()
+	limit := app.readInt(r.URL.Query(), "limit", 20, v)
+	v.Check(limit > 0, "limit", "must be greater than zero")
+	v.Check(limit <= 100, "limit", "must be a maximum of 100")
+	if !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	_, err = app.models.Users.GetById(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	attempts, err := app.models.LoginAttempts.GetForUser(id, limit)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"login_attempts": attempts}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
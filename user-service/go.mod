@@ -3,7 +3,10 @@ module user-service
 go 1.19
 
 require (
+	github.com/google/uuid v1.3.0
 	github.com/julienschmidt/httprouter v1.3.0
 	github.com/lib/pq v1.10.2
 	golang.org/x/crypto v0.10.0
 )
+
+require gopkg.in/yaml.v3 v3.0.1
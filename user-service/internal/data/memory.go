@@ -0,0 +1,238 @@
+package data
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemoryTokenModel is a TokenRepository that keeps tokens in process memory,
+// for running handlers without a database.
+type MemoryTokenModel struct {
+	mu     sync.Mutex
+	tokens map[[sha256.Size]byte]Token
+}
+
+func NewMemoryTokenModel() *MemoryTokenModel {
+	return &MemoryTokenModel{tokens: make(map[[sha256.Size]byte]Token)}
+}
+
+func (m *MemoryTokenModel) New(userID int64, ttl time.Duration, scope string) (*Token, error) {
+	token, err := generateToken(userID, ttl, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.Insert(token)
+	return token, err
+}
+
+func (m *MemoryTokenModel) Insert(token *Token) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.insert(token)
+	return nil
+}
+
+func (m *MemoryTokenModel) insert(token *Token) {
+	var key [sha256.Size]byte
+	copy(key[:], token.Hash)
+
+	m.tokens[key] = *token
+}
+
+func (m *MemoryTokenModel) NewPair(userID int64, accessTTL, refreshTTL time.Duration) (*Token, *Token, error) {
+	access, refresh, err := generatePair(userID, nil, accessTTL, refreshTTL)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.insert(access)
+	m.insert(refresh)
+	return access, refresh, nil
+}
+
+func (m *MemoryTokenModel) Rotate(refreshPlaintext string, accessTTL, refreshTTL time.Duration) (*Token, *Token, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := sha256.Sum256([]byte(refreshPlaintext))
+	token, ok := m.tokens[key]
+	now := time.Now()
+
+	switch {
+	case !ok || token.Scope != ScopeRefresh:
+		return nil, nil, ErrInvalidToken
+	case token.UsedAt != nil:
+		m.deleteFamily(token.Family)
+		return nil, nil, ErrTokenReused
+	case !token.Expiry.After(now):
+		return nil, nil, ErrInvalidToken
+	}
+
+	token.UsedAt = &now
+	m.tokens[key] = token
+
+	for key, other := range m.tokens {
+		if other.Scope == ScopeAuthentication && bytes.Equal(other.Family, token.Family) {
+			delete(m.tokens, key)
+		}
+	}
+
+	access, refresh, err := generatePair(token.UserID, token.Family, accessTTL, refreshTTL)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	m.insert(access)
+	m.insert(refresh)
+	return access, refresh, nil
+}
+
+func (m *MemoryTokenModel) RevokeFamily(tokenPlaintext string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := sha256.Sum256([]byte(tokenPlaintext))
+	token, ok := m.tokens[key]
+	if !ok {
+		return nil
+	}
+
+	delete(m.tokens, key)
+	m.deleteFamily(token.Family)
+	return nil
+}
+
+func (m *MemoryTokenModel) deleteFamily(family []byte) {
+	if family == nil {
+		return
+	}
+
+	for key, token := range m.tokens {
+		if bytes.Equal(token.Family, family) {
+			delete(m.tokens, key)
+		}
+	}
+}
+
+func (m *MemoryTokenModel) DeleteAllForUser(scope string, userID int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for key, token := range m.tokens {
+		if token.Scope == scope && token.UserID == userID {
+			delete(m.tokens, key)
+		}
+	}
+	return nil
+}
+
+func (m *MemoryTokenModel) Delete(scope, tokenPlaintext string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := sha256.Sum256([]byte(tokenPlaintext))
+	if token, ok := m.tokens[key]; ok && token.Scope == scope {
+		delete(m.tokens, key)
+	}
+	return nil
+}
+
+func (m *MemoryTokenModel) DeleteExpired() (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var deleted int64
+	now := time.Now()
+	for key, token := range m.tokens {
+		if !token.Expiry.After(now) {
+			delete(m.tokens, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func (m *MemoryTokenModel) Touch(tokenPlaintext string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := sha256.Sum256([]byte(tokenPlaintext))
+	current, ok := m.tokens[key]
+	if !ok {
+		return nil
+	}
+
+	now := time.Now()
+	for key, token := range m.tokens {
+		if !bytes.Equal(token.Hash, current.Hash) && (current.Family == nil || !bytes.Equal(token.Family, current.Family)) {
+			continue
+		}
+
+		if token.LastUsedAt == nil || token.LastUsedAt.Before(now.Add(-touchInterval)) {
+			token.LastUsedAt = &now
+			m.tokens[key] = token
+		}
+	}
+	return nil
+}
+
+func (m *MemoryTokenModel) GetSessions(userID int64, currentPlaintext string) ([]*Session, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	current := m.tokens[sha256.Sum256([]byte(currentPlaintext))]
+	now := time.Now()
+
+	sessions := []*Session{}
+	for _, token := range m.tokens {
+		if token.UserID != userID || token.Scope != ScopeRefresh || token.UsedAt != nil || !token.Expiry.After(now) {
+			continue
+		}
+
+		sessions = append(sessions, &Session{
+			CreatedAt:  token.CreatedAt,
+			LastUsedAt: token.LastUsedAt,
+			Expiry:     token.Expiry,
+			Current:    current.Family != nil && bytes.Equal(token.Family, current.Family),
+		})
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].CreatedAt.After(sessions[j].CreatedAt)
+	})
+
+	return sessions, nil
+}
+
+// UserIDForToken returns the owner of an unexpired token with the given scope.
+func (m *MemoryTokenModel) UserIDForToken(scope, tokenPlaintext string) (int64, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	token, ok := m.tokens[sha256.Sum256([]byte(tokenPlaintext))]
+	if !ok || token.Scope != scope || !token.Expiry.After(time.Now()) {
+		return 0, false
+	}
+	return token.UserID, true
+}
+
+// PurgeUser removes every token of the user, as the ON DELETE CASCADE on
+// tokens.user_id does in Postgres.
+func (m *MemoryTokenModel) PurgeUser(userID int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for key, token := range m.tokens {
+		if token.UserID == userID {
+			delete(m.tokens, key)
+		}
+	}
+}
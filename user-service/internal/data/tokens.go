@@ -6,6 +6,7 @@ import (
 	"crypto/sha256"
 	"database/sql"
 	"encoding/base32"
+	"errors"
 	"time"
 	"user-service/internal/validator"
 )
@@ -13,25 +14,63 @@ import (
 const (
 	ScopeActivation     = "activation"
 	ScopeAuthentication = "authentication"
+	ScopePasswordReset  = "password-reset"
+	ScopeRefresh        = "refresh"
 )
 
+var (
+	ErrInvalidToken = errors.New("invalid or expired token")
+	ErrTokenReused  = errors.New("refresh token reused")
+)
+
+type TokenRepository interface {
+	New(userID int64, ttl time.Duration, scope string) (*Token, error)
+	Insert(token *Token) error
+	Delete(scope, tokenPlaintext string) error
+	DeleteAllForUser(scope string, userID int64) error
+	DeleteExpired() (int64, error)
+	Touch(tokenPlaintext string) error
+	GetSessions(userID int64, currentPlaintext string) ([]*Session, error)
+	NewPair(userID int64, accessTTL, refreshTTL time.Duration) (*Token, *Token, error)
+	Rotate(refreshPlaintext string, accessTTL, refreshTTL time.Duration) (*Token, *Token, error)
+	RevokeFamily(tokenPlaintext string) error
+}
+
 type TokenModel struct {
 	DB *sql.DB
 }
 
 type Token struct {
-	Plaintext string    `json:"token"`
-	Hash      []byte    `json:"-"`
-	UserID    int64     `json:"-"`
-	Expiry    time.Time `json:"expiry"`
-	Scope     string    `json:"-"`
+	Plaintext  string     `json:"token"`
+	Hash       []byte     `json:"-"`
+	UserID     int64      `json:"-"`
+	Expiry     time.Time  `json:"expiry"`
+	Scope      string     `json:"-"`
+	CreatedAt  time.Time  `json:"-"`
+	LastUsedAt *time.Time `json:"-"`
+	Family     []byte     `json:"-"`
+	UsedAt     *time.Time `json:"-"`
 }
 
+// Session describes a login, identified by its refresh token family, without
+// revealing any of its tokens.
+type Session struct {
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	Expiry     time.Time  `json:"expiry"`
+	Current    bool       `json:"current"`
+}
+
+// touchInterval limits how often last_used_at is written for a token that is
+// used on every request.
+const touchInterval = time.Minute
+
 func generateToken(userID int64, ttl time.Duration, scope string) (*Token, error) {
 	token := &Token{
-		UserID: userID,
-		Expiry: time.Now().Add(ttl),
-		Scope:  scope,
+		UserID:    userID,
+		Expiry:    time.Now().Add(ttl),
+		Scope:     scope,
+		CreatedAt: time.Now(),
 	}
 
 	randomBytes := make([]byte, 16)
@@ -57,14 +96,173 @@ func (m TokenModel) New(userID int64, ttl time.Duration, scope string) (*Token,
 	return token, err
 }
 
+// generatePair creates an access and a refresh token sharing a new family, so
+// that the whole login can be revoked at once.
+func generatePair(userID int64, family []byte, accessTTL, refreshTTL time.Duration) (*Token, *Token, error) {
+	if family == nil {
+		family = make([]byte, 16)
+		_, err := rand.Read(family)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	access, err := generateToken(userID, accessTTL, ScopeAuthentication)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	refresh, err := generateToken(userID, refreshTTL, ScopeRefresh)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	access.Family = family
+	refresh.Family = family
+	return access, refresh, nil
+}
+
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+func insertToken(ctx context.Context, db execer, token *Token) error {
+	query := `
+		INSERT INTO tokens (hash, user_id, expiry, scope, created_at, family)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+	args := []any{token.Hash, token.UserID, token.Expiry, token.Scope, token.CreatedAt, token.Family}
+	_, err := db.ExecContext(ctx, query, args...)
+
+	return err
+}
+
 func (m TokenModel) Insert(token *Token) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	return insertToken(ctx, m.DB, token)
+}
+
+func (m TokenModel) NewPair(userID int64, accessTTL, refreshTTL time.Duration) (*Token, *Token, error) {
+	access, refresh, err := generatePair(userID, nil, accessTTL, refreshTTL)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback()
+
+	for _, token := range []*Token{access, refresh} {
+		err = insertToken(ctx, tx, token)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return access, refresh, tx.Commit()
+}
+
+// Rotate exchanges a refresh token for a new access and refresh token in the
+// same family. The old refresh token is kept, marked as used, so that
+// presenting it again is detected as reuse and revokes the whole family.
+func (m TokenModel) Rotate(refreshPlaintext string, accessTTL, refreshTTL time.Duration) (*Token, *Token, error) {
+	refreshHash := sha256.Sum256([]byte(refreshPlaintext))
+	now := time.Now()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE tokens
+		SET used_at = $1
+		WHERE hash = $2 AND scope = $3 AND expiry > $1 AND used_at IS NULL
+		RETURNING user_id, family`
+
+	var (
+		userID int64
+		family []byte
+	)
+
+	err = tx.QueryRowContext(ctx, query, now, refreshHash[:], ScopeRefresh).Scan(&userID, &family)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, err
+		}
+
+		query = `
+			DELETE FROM tokens
+			WHERE family = (
+				SELECT family FROM tokens
+				WHERE hash = $1 AND scope = $2 AND used_at IS NOT NULL
+			)`
+
+		result, err := tx.ExecContext(ctx, query, refreshHash[:], ScopeRefresh)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		revoked, err := result.RowsAffected()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if revoked == 0 {
+			return nil, nil, ErrInvalidToken
+		}
+
+		err = tx.Commit()
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, nil, ErrTokenReused
+	}
+
+	query = `
+		DELETE FROM tokens
+		WHERE family = $1 AND scope = $2`
+
+	_, err = tx.ExecContext(ctx, query, family, ScopeAuthentication)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	access, refresh, err := generatePair(userID, family, accessTTL, refreshTTL)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, token := range []*Token{access, refresh} {
+		err = insertToken(ctx, tx, token)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return access, refresh, tx.Commit()
+}
+
+// RevokeFamily deletes the token and every other token issued for the same
+// login.
+func (m TokenModel) RevokeFamily(tokenPlaintext string) error {
+	tokenHash := sha256.Sum256([]byte(tokenPlaintext))
 	query := `
-		INSERT INTO tokens (hash, user_id, expiry, scope)
-		VALUES ($1, $2, $3, $4)`
-	args := []any{token.Hash, token.UserID, token.Expiry, token.Scope}
+		DELETE FROM tokens
+		WHERE hash = $1 OR family = (SELECT family FROM tokens WHERE hash = $1)`
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
-	_, err := m.DB.ExecContext(ctx, query, args...)
+	_, err := m.DB.ExecContext(ctx, query, tokenHash[:])
 
 	return err
 }
@@ -80,6 +278,77 @@ func (m TokenModel) DeleteAllForUser(scope string, userID int64) error {
 	return err
 }
 
+func (m TokenModel) Delete(scope, tokenPlaintext string) error {
+	tokenHash := sha256.Sum256([]byte(tokenPlaintext))
+	query := `
+		DELETE FROM tokens
+		WHERE scope = $1 AND hash = $2`
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	_, err := m.DB.ExecContext(ctx, query, scope, tokenHash[:])
+
+	return err
+}
+
+func (m TokenModel) DeleteExpired() (int64, error) {
+	query := `
+		DELETE FROM tokens
+		WHERE expiry <= $1`
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	result, err := m.DB.ExecContext(ctx, query, time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (m TokenModel) Touch(tokenPlaintext string) error {
+	tokenHash := sha256.Sum256([]byte(tokenPlaintext))
+	query := `
+		UPDATE tokens
+		SET last_used_at = $1
+		WHERE (hash = $2 OR family = (SELECT family FROM tokens WHERE hash = $2))
+		AND (last_used_at IS NULL OR last_used_at < $3)`
+	now := time.Now()
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	_, err := m.DB.ExecContext(ctx, query, now, tokenHash[:], now.Add(-touchInterval))
+
+	return err
+}
+
+func (m TokenModel) GetSessions(userID int64, currentPlaintext string) ([]*Session, error) {
+	currentHash := sha256.Sum256([]byte(currentPlaintext))
+	query := `
+		SELECT created_at, last_used_at, expiry,
+			COALESCE(family = (SELECT family FROM tokens WHERE hash = $1), false)
+		FROM tokens
+		WHERE user_id = $2 AND scope = $3 AND used_at IS NULL AND expiry > $4
+		ORDER BY created_at DESC`
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := m.DB.QueryContext(ctx, query, currentHash[:], userID, ScopeRefresh, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := []*Session{}
+	for rows.Next() {
+		var session Session
+		err := rows.Scan(&session.CreatedAt, &session.LastUsedAt, &session.Expiry, &session.Current)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, &session)
+	}
+
+	return sessions, rows.Err()
+}
+
 func ValidateTokenPlaintext(v *validator.Validator, tokenPlaintext string) {
 	v.Check(tokenPlaintext != "", "token", "must be provided")
 	v.Check(len(tokenPlaintext) == 26, "token", "must be 26 bytes long")
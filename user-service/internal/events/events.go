@@ -0,0 +1,72 @@
+// Package events writes domain events to the outbox table shared with the
+// authorization service, whose relay publishes them. Events are written in the
+// same transaction as the change they describe. The envelope and payloads must
+// stay in step with authorization/events.
+package events
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Version is the envelope version. It changes when the envelope or an event
+// payload changes incompatibly.
+const Version = 1
+
+const TypeUserRegistered = "user.registered"
+
+type Envelope struct {
+	ID         string          `json:"id"`
+	Type       string          `json:"type"`
+	Version    int             `json:"version"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+func NewEnvelope(eventType string, data any) (Envelope, error) {
+	js, err := json.Marshal(data)
+	if err != nil {
+		return Envelope{}, err
+	}
+
+	envelope := Envelope{
+		ID:         uuid.NewString(),
+		Type:       eventType,
+		Version:    Version,
+		OccurredAt: time.Now().UTC(),
+		Data:       js,
+	}
+
+	return envelope, nil
+}
+
+type UserRegistered struct {
+	UserID  int64  `json:"user_id"`
+	Name    string `json:"name"`
+	Surname string `json:"surname"`
+	Email   string `json:"email"`
+}
+
+// Enqueue records an event in the outbox as part of tx. It is published once
+// tx has committed and the relay picks it up.
+func Enqueue(ctx context.Context, tx *sql.Tx, eventType string, data any) error {
+	envelope, err := NewEnvelope(eventType, data)
+	if err != nil {
+		return err
+	}
+
+	js, err := json.Marshal(envelope)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO outbox (id, type, envelope, created_at)
+		VALUES ($1, $2, $3, $4)`,
+		envelope.ID, envelope.Type, js, envelope.OccurredAt)
+	return err
+}
@@ -0,0 +1,180 @@
+package mailer
+
+import (
+	"bytes"
+	"crypto/rand"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"html/template"
+	"io"
+	"mime"
+	"net/mail"
+	"net/smtp"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	ttemplate "text/template"
+	"time"
+)
+
+//go:embed "templates"
+var templateFS embed.FS
+
+// Transport delivers a fully rendered message.
+type Transport interface {
+	Send(from string, to []string, msg []byte) error
+}
+
+type Mailer struct {
+	transport Transport
+	sender    string
+}
+
+func New(transport Transport, sender string) Mailer {
+	return Mailer{
+		transport: transport,
+		sender:    sender,
+	}
+}
+
+// Send renders the named template from the templates directory with data and
+// delivers it to recipient. Each template defines a "subject", "plainBody"
+// and "htmlBody" block.
+func (m Mailer) Send(recipient, templateFile string, data any) error {
+	textTmpl, err := ttemplate.New("email").ParseFS(templateFS, "templates/"+templateFile)
+	if err != nil {
+		return err
+	}
+
+	subject := new(bytes.Buffer)
+	err = textTmpl.ExecuteTemplate(subject, "subject", data)
+	if err != nil {
+		return err
+	}
+
+	plainBody := new(bytes.Buffer)
+	err = textTmpl.ExecuteTemplate(plainBody, "plainBody", data)
+	if err != nil {
+		return err
+	}
+
+	htmlTmpl, err := template.New("email").ParseFS(templateFS, "templates/"+templateFile)
+	if err != nil {
+		return err
+	}
+
+	htmlBody := new(bytes.Buffer)
+	err = htmlTmpl.ExecuteTemplate(htmlBody, "htmlBody", data)
+	if err != nil {
+		return err
+	}
+
+	// The sender may carry a display name, which only belongs in the From
+	// header. The envelope sender has to be the bare address.
+	from, err := mail.ParseAddress(m.sender)
+	if err != nil {
+		return fmt.Errorf("mailer: invalid sender %q: %w", m.sender, err)
+	}
+
+	msg, err := buildMessage(m.sender, recipient, strings.TrimSpace(subject.String()), plainBody.String(), htmlBody.String())
+	if err != nil {
+		return err
+	}
+
+	for i := 1; i <= 3; i++ {
+		err = m.transport.Send(from.Address, []string{recipient}, msg)
+		if err == nil {
+			return nil
+		}
+
+		time.Sleep(500 * time.Millisecond)
+	}
+
+	return err
+}
+
+func buildMessage(from, to, subject, plainBody, htmlBody string) ([]byte, error) {
+	boundaryBytes := make([]byte, 12)
+	_, err := rand.Read(boundaryBytes)
+	if err != nil {
+		return nil, err
+	}
+	boundary := hex.EncodeToString(boundaryBytes)
+
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", to)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&b, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&b, "Content-Type: multipart/alternative; boundary=%q\r\n\r\n", boundary)
+
+	fmt.Fprintf(&b, "--%s\r\n", boundary)
+	fmt.Fprintf(&b, "Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	fmt.Fprintf(&b, "%s\r\n", plainBody)
+
+	fmt.Fprintf(&b, "--%s\r\n", boundary)
+	fmt.Fprintf(&b, "Content-Type: text/html; charset=utf-8\r\n\r\n")
+	fmt.Fprintf(&b, "%s\r\n", htmlBody)
+
+	fmt.Fprintf(&b, "--%s--\r\n", boundary)
+
+	return b.Bytes(), nil
+}
+
+// SMTPTransport sends messages through an SMTP server, authenticating with
+// PLAIN auth when a username is set.
+type SMTPTransport struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+}
+
+func (t SMTPTransport) Send(from string, to []string, msg []byte) error {
+	var auth smtp.Auth
+	if t.Username != "" {
+		auth = smtp.PlainAuth("", t.Username, t.Password, t.Host)
+	}
+
+	addr := t.Host + ":" + strconv.Itoa(t.Port)
+
+	return smtp.SendMail(addr, auth, from, to, msg)
+}
+
+// WriterTransport writes every message to an io.Writer instead of sending it.
+// It stands in for an SMTP server during development and in tests.
+type WriterTransport struct {
+	mu  sync.Mutex
+	out io.Writer
+}
+
+func NewWriterTransport(out io.Writer) *WriterTransport {
+	return &WriterTransport{out: out}
+}
+
+// NewFileTransport returns a WriterTransport appending to the file at path,
+// or writing to stdout when path is "-".
+func NewFileTransport(path string) (*WriterTransport, error) {
+	if path == "-" {
+		return NewWriterTransport(os.Stdout), nil
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewWriterTransport(file), nil
+}
+
+func (t *WriterTransport) Send(from string, to []string, msg []byte) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	_, err := fmt.Fprintf(t.out, "MAIL FROM:<%s> RCPT TO:<%s>\r\n%s\r\n.\r\n", from, strings.Join(to, ","), msg)
+	return err
+}
@@ -0,0 +1,86 @@
+package mailer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSend(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		data     map[string]any
+		want     []string
+	}{
+		{
+			name:     "Welcome",
+			template: "user_welcome.tmpl",
+			data:     map[string]any{"userName": "Aldi", "userID": 7, "activationToken": "ABCDEFGHIJKLMNOPQRSTUVWXYZ"},
+			want:     []string{"Subject: Welcome to Miracle!", "Hi Aldi", "ABCDEFGHIJKLMNOPQRSTUVWXYZ"},
+		},
+		{
+			name:     "PasswordReset",
+			template: "password_reset.tmpl",
+			data:     map[string]any{"userName": "Aldi", "passwordResetToken": "ZYXWVUTSRQPONMLKJIHGFEDCBA"},
+			want:     []string{"Subject: Reset your Miracle password", "ZYXWVUTSRQPONMLKJIHGFEDCBA"},
+		},
+		{
+			name:     "RentalConfirmation",
+			template: "rental_confirmation.tmpl",
+			data:     map[string]any{"userName": "<b>Aldi</b>", "carBrand": "Toyota", "carID": 3},
+			want:     []string{"Subject: Your Toyota rental is confirmed", "Hi <b>Aldi</b>,", "Hi &lt;b&gt;Aldi&lt;/b&gt;,"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			m := New(NewWriterTransport(&out), "Miracle <no-reply@miracle.kz>")
+
+			err := m.Send("user@example.com", tt.template, tt.data)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, want := range tt.want {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("expected message to contain %q, got:\n%s", want, out.String())
+				}
+			}
+		})
+	}
+}
+
+type recordingTransport struct {
+	from string
+	to   []string
+	msg  []byte
+}
+
+func (t *recordingTransport) Send(from string, to []string, msg []byte) error {
+	t.from, t.to, t.msg = from, to, msg
+	return nil
+}
+
+func TestSendEnvelopeSender(t *testing.T) {
+	var transport recordingTransport
+	m := New(&transport, "Miracle <no-reply@miracle.kz>")
+
+	err := m.Send("user@example.com", "user_welcome.tmpl", map[string]any{"userName": "Aldi", "userID": 7, "activationToken": "ABCDEFGHIJKLMNOPQRSTUVWXYZ"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if transport.from != "no-reply@miracle.kz" {
+		t.Errorf("envelope sender: got %q, want %q", transport.from, "no-reply@miracle.kz")
+	}
+	if !bytes.Contains(transport.msg, []byte("From: Miracle <no-reply@miracle.kz>\r\n")) {
+		t.Errorf("expected the From header to keep the display name, got:\n%s", transport.msg)
+	}
+
+	err = New(&transport, "not an address").Send("user@example.com", "user_welcome.tmpl", nil)
+	if err == nil {
+		t.Error("expected an error for an invalid sender")
+	}
+}
@@ -0,0 +1,71 @@
+package migrate
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    fstest.MapFS
+		versions []int64
+		wantErr  bool
+	}{
+		{
+			name: "Sorted by version",
+			files: fstest.MapFS{
+				"000002_create_b.up.sql":   {Data: []byte("CREATE TABLE b ();")},
+				"000002_create_b.down.sql": {Data: []byte("DROP TABLE b;")},
+				"000001_create_a.up.sql":   {Data: []byte("CREATE TABLE a ();")},
+				"000001_create_a.down.sql": {Data: []byte("DROP TABLE a;")},
+			},
+			versions: []int64{1, 2},
+		},
+		{
+			name: "Missing up script",
+			files: fstest.MapFS{
+				"000001_create_a.down.sql": {Data: []byte("DROP TABLE a;")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Invalid version",
+			files: fstest.MapFS{
+				"first_create_a.up.sql": {Data: []byte("CREATE TABLE a ();")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Unknown direction",
+			files: fstest.MapFS{
+				"000001_create_a.sql": {Data: []byte("CREATE TABLE a ();")},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			migrations, err := Load(tt.files)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(migrations) != len(tt.versions) {
+				t.Fatalf("expected %d migrations, got %d", len(tt.versions), len(migrations))
+			}
+			for i, version := range tt.versions {
+				if migrations[i].Version != version {
+					t.Errorf("expected migration %d to have version %d, got %d", i, version, migrations[i].Version)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// LoginAttempt is an entry of the login audit log. UserID is nil when the
+// email doesn't belong to an account.
+type LoginAttempt struct {
+	ID        int64     `json:"id"`
+	UserID    *int64    `json:"user_id,omitempty"`
+	Email     string    `json:"email"`
+	IP        string    `json:"ip"`
+	Succeeded bool      `json:"succeeded"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type LoginAttemptRepository interface {
+	Insert(attempt *LoginAttempt) error
+	CountFailuresForIP(ip string, since time.Time) (int, error)
+	GetForUser(userID int64, limit int) ([]*LoginAttempt, error)
+}
+
+type LoginAttemptModel struct {
+	DB *sql.DB
+}
+
+func (m LoginAttemptModel) Insert(attempt *LoginAttempt) error {
+	query := `
+		INSERT INTO login_attempts (user_id, email, ip, succeeded)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at`
+	args := []any{attempt.UserID, attempt.Email, attempt.IP, attempt.Succeeded}
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	return m.DB.QueryRowContext(ctx, query, args...).Scan(&attempt.ID, &attempt.CreatedAt)
+}
+
+func (m LoginAttemptModel) CountFailuresForIP(ip string, since time.Time) (int, error) {
+	query := `
+		SELECT count(*)
+		FROM login_attempts
+		WHERE ip = $1 AND NOT succeeded AND created_at > $2`
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var failures int
+	err := m.DB.QueryRowContext(ctx, query, ip, since).Scan(&failures)
+	return failures, err
+}
+
+func (m LoginAttemptModel) GetForUser(userID int64, limit int) ([]*LoginAttempt, error) {
+	query := `
+		SELECT id, user_id, email, ip, succeeded, created_at
+		FROM login_attempts
+		WHERE user_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2`
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := m.DB.QueryContext(ctx, query, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	attempts := []*LoginAttempt{}
+	for rows.Next() {
+		var attempt LoginAttempt
+		err := rows.Scan(
+			&attempt.ID,
+			&attempt.UserID,
+			&attempt.Email,
+			&attempt.IP,
+			&attempt.Succeeded,
+			&attempt.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		attempts = append(attempts, &attempt)
+	}
+
+	return attempts, rows.Err()
+}
@@ -0,0 +1,226 @@
+package models
+
+import (
+	"sort"
+	"sync"
+	"time"
+	"user-service/internal/data"
+)
+
+type memoryUserModel struct {
+	mu     sync.Mutex
+	users  map[int64]User
+	lastID int64
+	tokens *data.MemoryTokenModel
+}
+
+// NewMemoryModels returns models backed by process memory instead of
+// Postgres, for running handlers without a database.
+func NewMemoryModels() Models {
+	tokens := data.NewMemoryTokenModel()
+
+	return Models{
+		Users: &memoryUserModel{
+			users:  make(map[int64]User),
+			tokens: tokens,
+		},
+		Tokens:        tokens,
+		LoginAttempts: &memoryLoginAttemptModel{},
+		Permissions:   memoryPermissionModel{},
+	}
+}
+
+func (m *memoryUserModel) Insert(user *User) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, u := range m.users {
+		if u.Email == user.Email {
+			return ErrDuplicateEmail
+		}
+	}
+
+	m.lastID++
+	user.ID = m.lastID
+	m.users[user.ID] = *user
+
+	return nil
+}
+
+func (m *memoryUserModel) GetByEmail(email string) (*User, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, u := range m.users {
+		if u.Email == email {
+			return &u, nil
+		}
+	}
+
+	return nil, ErrRecordNotFound
+}
+
+func (m *memoryUserModel) GetById(id int64) (*User, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	u, ok := m.users[id]
+	if !ok {
+		return nil, ErrRecordNotFound
+	}
+
+	return &u, nil
+}
+
+func (m *memoryUserModel) Update(user *User) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.users[user.ID]; !ok {
+		return ErrRecordNotFound
+	}
+
+	for _, u := range m.users {
+		if u.ID != user.ID && u.Email == user.Email {
+			return ErrDuplicateEmail
+		}
+	}
+
+	m.users[user.ID] = *user
+
+	return nil
+}
+
+func (m *memoryUserModel) Delete(id int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.users[id]; !ok {
+		return ErrRecordNotFound
+	}
+
+	delete(m.users, id)
+	m.tokens.PurgeUser(id)
+
+	return nil
+}
+
+func (m *memoryUserModel) GetForToken(tokenScope, tokenPlaintext string) (*User, error) {
+	userID, ok := m.tokens.UserIDForToken(tokenScope, tokenPlaintext)
+	if !ok {
+		return nil, ErrRecordNotFound
+	}
+
+	return m.GetById(userID)
+}
+
+func (m *memoryUserModel) RecordFailedLogin(id int64, maxFailures int, lockout time.Duration) (*User, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	u, ok := m.users[id]
+	if !ok {
+		return nil, ErrRecordNotFound
+	}
+
+	u.FailedLogins++
+	if maxFailures > 0 && u.FailedLogins >= maxFailures {
+		lockedUntil := time.Now().Add(lockout)
+		u.LockedUntil = &lockedUntil
+	}
+	m.users[id] = u
+
+	return &u, nil
+}
+
+func (m *memoryUserModel) ResetFailedLogins(id int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	u, ok := m.users[id]
+	if !ok {
+		return ErrRecordNotFound
+	}
+
+	u.FailedLogins = 0
+	u.LockedUntil = nil
+	m.users[id] = u
+
+	return nil
+}
+
+type memoryLoginAttemptModel struct {
+	mu       sync.Mutex
+	attempts []LoginAttempt
+}
+
+func (m *memoryLoginAttemptModel) Insert(attempt *LoginAttempt) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	attempt.ID = int64(len(m.attempts) + 1)
+	attempt.CreatedAt = time.Now()
+	m.attempts = append(m.attempts, *attempt)
+
+	return nil
+}
+
+func (m *memoryLoginAttemptModel) CountFailuresForIP(ip string, since time.Time) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	failures := 0
+	for _, attempt := range m.attempts {
+		if attempt.IP == ip && !attempt.Succeeded && attempt.CreatedAt.After(since) {
+			failures++
+		}
+	}
+
+	return failures, nil
+}
+
+func (m *memoryLoginAttemptModel) GetForUser(userID int64, limit int) ([]*LoginAttempt, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	attempts := []*LoginAttempt{}
+	for i := range m.attempts {
+		attempt := m.attempts[i]
+		if attempt.UserID != nil && *attempt.UserID == userID {
+			attempts = append(attempts, &attempt)
+		}
+	}
+
+	sort.Slice(attempts, func(i, j int) bool {
+		return attempts[i].ID > attempts[j].ID
+	})
+
+	if len(attempts) > limit {
+		attempts = attempts[:limit]
+	}
+
+	return attempts, nil
+}
+
+// rolePermissions mirrors the rows the permissions migration seeds.
+var rolePermissions = map[string]Permissions{
+	RoleDefault:   {PermissionCarsWrite},
+	RoleModerator: {PermissionCarsWrite, PermissionCarsModerate, PermissionRentalsRead, PermissionUsersRead},
+	RoleAdmin:     {PermissionCarsWrite, PermissionCarsModerate, PermissionRentalsRead, PermissionUsersRead, PermissionUsersAdmin},
+}
+
+type memoryPermissionModel struct{}
+
+func (memoryPermissionModel) GetAllForRoles(roles []string) (Permissions, error) {
+	permissions := Permissions{}
+	for _, role := range roles {
+		for _, code := range rolePermissions[role] {
+			if !permissions.Include(code) {
+				permissions = append(permissions, code)
+			}
+		}
+	}
+
+	sort.Strings(permissions)
+	return permissions, nil
+}
@@ -11,13 +11,17 @@ var (
 )
 
 type Models struct {
-	Users  UserModel
-	Tokens data.TokenModel
+	Users         UserRepository
+	Tokens        data.TokenRepository
+	LoginAttempts LoginAttemptRepository
+	Permissions   PermissionRepository
 }
 
 func NewModels(db *sql.DB) Models {
 	return Models{
-		Tokens: data.TokenModel{DB: db},
-		Users:  UserModel{DB: db},
+		Tokens:        data.TokenModel{DB: db},
+		Users:         UserModel{DB: db},
+		LoginAttempts: LoginAttemptModel{DB: db},
+		Permissions:   PermissionModel{DB: db},
 	}
 }
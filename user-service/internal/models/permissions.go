@@ -0,0 +1,85 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+	"user-service/internal/validator"
+
+	"github.com/lib/pq"
+)
+
+const (
+	RoleDefault   = "DEFAULT"
+	RoleModerator = "MODERATOR"
+	RoleAdmin     = "ADMIN"
+)
+
+// AllRoles is the fixed set of roles a user can hold.
+var AllRoles = []string{RoleDefault, RoleModerator, RoleAdmin}
+
+const (
+	PermissionCarsWrite    = "cars:write"
+	PermissionCarsModerate = "cars:moderate"
+	PermissionRentalsRead  = "rentals:read"
+	PermissionUsersRead    = "users:read"
+	PermissionUsersAdmin   = "users:admin"
+)
+
+type Permissions []string
+
+func (p Permissions) Include(code string) bool {
+	for i := range p {
+		if code == p[i] {
+			return true
+		}
+	}
+	return false
+}
+
+type PermissionRepository interface {
+	GetAllForRoles(roles []string) (Permissions, error)
+}
+
+type PermissionModel struct {
+	DB *sql.DB
+}
+
+func (m PermissionModel) GetAllForRoles(roles []string) (Permissions, error) {
+	query := `
+		SELECT DISTINCT code
+		FROM permissions
+		WHERE role = ANY($1)
+		ORDER BY code`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := m.DB.QueryContext(ctx, query, pq.Array(roles))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	permissions := Permissions{}
+	for rows.Next() {
+		var code string
+		err := rows.Scan(&code)
+		if err != nil {
+			return nil, err
+		}
+		permissions = append(permissions, code)
+	}
+
+	return permissions, rows.Err()
+}
+
+func ValidateRoles(v *validator.Validator, roles []string) {
+	v.Check(len(roles) > 0, "roles", "must contain at least 1 role")
+	v.Check(validator.Unique(roles), "roles", "must not contain duplicate values")
+
+	for _, role := range roles {
+		v.Check(validator.PermittedValue(role, AllRoles...), "roles", "must only contain "+strings.Join(AllRoles, ", "))
+	}
+}
@@ -5,23 +5,45 @@ import (
 	"crypto/sha256"
 	"database/sql"
 	"errors"
+	"github.com/lib/pq"
 	"golang.org/x/crypto/bcrypt"
 	"time"
+	"user-service/internal/events"
 	"user-service/internal/validator"
 )
 
 type User struct {
-	ID        int64    `json:"id"`
-	Name      string   `json:"name"`
-	Surname   string   `json:"surname"`
-	Email     string   `json:"email"`
-	Password  password `json:"-"`
-	Activated bool     `json:"activated"`
-	Roles     string   `json:"roles"`
+	ID           int64       `json:"id"`
+	Name         string      `json:"name"`
+	Surname      string      `json:"surname"`
+	Email        string      `json:"email"`
+	Password     password    `json:"-"`
+	Activated    bool        `json:"activated"`
+	Roles        []string    `json:"roles"`
+	Permissions  Permissions `json:"permissions,omitempty"`
+	FailedLogins int         `json:"-"`
+	LockedUntil  *time.Time  `json:"locked_until,omitempty"`
 }
 
 var AnonymousUser = &User{}
 
+type UserRepository interface {
+	Insert(user *User) error
+	GetByEmail(email string) (*User, error)
+	GetById(id int64) (*User, error)
+	Update(user *User) error
+	Delete(id int64) error
+	GetForToken(tokenScope, tokenPlaintext string) (*User, error)
+	RecordFailedLogin(id int64, maxFailures int, lockout time.Duration) (*User, error)
+	ResetFailedLogins(id int64) error
+}
+
+// IsLocked reports whether the account is locked out after too many failed
+// logins.
+func (u *User) IsLocked() bool {
+	return u.LockedUntil != nil && u.LockedUntil.After(time.Now())
+}
+
 type UserModel struct {
 	DB *sql.DB
 }
@@ -94,11 +116,17 @@ func (m UserModel) Insert(user *User) error {
 INSERT INTO users (name, surname, email, password_hash, activated, roles)
 VALUES ($1, $2, $3, $4 , $5, $6)
 RETURNING id`
-	args := []any{user.Name, user.Surname, user.Email, user.Password.hash, user.Activated, user.Roles}
+	args := []any{user.Name, user.Surname, user.Email, user.Password.hash, user.Activated, pq.Array(user.Roles)}
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
-	err := m.DB.QueryRowContext(ctx, query, args...).Scan(&user.ID)
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, query, args...).Scan(&user.ID)
 	if err != nil {
 		switch {
 		case err.Error() == `pq: duplicate key value violates unique constraint "users_email_key"`:
@@ -107,12 +135,23 @@ RETURNING id`
 			return err
 		}
 	}
-	return nil
+
+	err = events.Enqueue(ctx, tx, events.TypeUserRegistered, events.UserRegistered{
+		UserID:  user.ID,
+		Name:    user.Name,
+		Surname: user.Surname,
+		Email:   user.Email,
+	})
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 func (m UserModel) GetByEmail(email string) (*User, error) {
 	query := `
-		SELECT id, name, surname, email, password_hash, activated, roles
+		SELECT id, name, surname, email, password_hash, activated, roles, failed_logins, locked_until
 		FROM users
 		WHERE email = $1`
 
@@ -126,7 +165,9 @@ func (m UserModel) GetByEmail(email string) (*User, error) {
 		&user.Email,
 		&user.Password.hash,
 		&user.Activated,
-		&user.Roles,
+		pq.Array(&user.Roles),
+		&user.FailedLogins,
+		&user.LockedUntil,
 	)
 	if err != nil {
 		switch {
@@ -144,7 +185,7 @@ func (m UserModel) GetById(id int64) (*User, error) {
 		return nil, ErrRecordNotFound
 	}
 
-	query := `SELECT id, name, surname, email, password_hash, activated, roles
+	query := `SELECT id, name, surname, email, password_hash, activated, roles, failed_logins, locked_until
 		FROM users
 		WHERE id = $1`
 
@@ -160,7 +201,9 @@ func (m UserModel) GetById(id int64) (*User, error) {
 		&user.Email,
 		&user.Password.hash,
 		&user.Activated,
-		&user.Roles,
+		pq.Array(&user.Roles),
+		&user.FailedLogins,
+		&user.LockedUntil,
 	)
 
 	if err != nil {
@@ -187,14 +230,20 @@ func (m UserModel) Update(user *User) error {
 		user.Email,
 		user.Password.hash,
 		user.Activated,
-		user.Roles,
+		pq.Array(user.Roles),
 		user.ID,
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
-	err := m.DB.QueryRowContext(ctx, query, args...).Scan(&user.ID)
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, query, args...).Scan(&user.ID)
 	if err != nil {
 		switch {
 		case err.Error() == `pq: duplicate key value violates unique constraint "users_email_key"`:
@@ -203,7 +252,18 @@ func (m UserModel) Update(user *User) error {
 			return err
 		}
 	}
-	return nil
+
+	err = events.Enqueue(ctx, tx, events.TypeUserRegistered, events.UserRegistered{
+		UserID:  user.ID,
+		Name:    user.Name,
+		Surname: user.Surname,
+		Email:   user.Email,
+	})
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 func (m UserModel) Delete(id int64) error {
@@ -232,6 +292,60 @@ func (m UserModel) Delete(id int64) error {
 	return nil
 }
 
+// RecordFailedLogin counts a failed login for the user and locks the account
+// for the lockout duration once maxFailures is reached. A maxFailures of 0
+// never locks the account.
+func (m UserModel) RecordFailedLogin(id int64, maxFailures int, lockout time.Duration) (*User, error) {
+	query := `
+		UPDATE users
+		SET failed_logins = failed_logins + 1,
+			locked_until = CASE WHEN $2 > 0 AND failed_logins + 1 >= $2 THEN $3 ELSE locked_until END
+		WHERE id = $1
+		RETURNING failed_logins, locked_until`
+
+	user := User{ID: id}
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	err := m.DB.QueryRowContext(ctx, query, id, maxFailures, time.Now().Add(lockout)).Scan(&user.FailedLogins, &user.LockedUntil)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrRecordNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return &user, nil
+}
+
+// ResetFailedLogins clears the failed login count and lifts any lockout.
+func (m UserModel) ResetFailedLogins(id int64) error {
+	query := `
+		UPDATE users
+		SET failed_logins = 0, locked_until = NULL
+		WHERE id = $1`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := m.DB.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+
+	return nil
+}
+
 func (m UserModel) GetForToken(tokenScope, tokenPlaintext string) (*User, error) {
 	tokenHash := sha256.Sum256([]byte(tokenPlaintext))
 	query := `
@@ -254,7 +368,7 @@ func (m UserModel) GetForToken(tokenScope, tokenPlaintext string) (*User, error)
 		&user.Email,
 		&user.Password.hash,
 		&user.Activated,
-		&user.Roles,
+		pq.Array(&user.Roles),
 	)
 
 	if err != nil {
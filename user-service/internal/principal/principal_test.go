@@ -0,0 +1,39 @@
+package principal
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSignVerify(t *testing.T) {
+	secret := []byte("secret")
+
+	value, err := Sign(Principal{UserID: 7, Permissions: []string{"cars:write"}}, secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := Verify(value, secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.UserID != 7 || !p.HasPermission("cars:write") || p.HasPermission("cars:moderate") {
+		t.Errorf("unexpected principal: %+v", p)
+	}
+
+	if _, err := Verify(value, []byte("other")); !errors.Is(err, ErrInvalid) {
+		t.Errorf("wrong secret: got %v", err)
+	}
+
+	forged, err := Sign(Principal{UserID: 1, Permissions: []string{"cars:moderate"}}, []byte("other"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload, _, _ := strings.Cut(forged, ".")
+	_, sig, _ := strings.Cut(value, ".")
+
+	if _, err := Verify(payload+"."+sig, secret); !errors.Is(err, ErrInvalid) {
+		t.Errorf("swapped payload: got %v", err)
+	}
+}
@@ -0,0 +1,98 @@
+package ratelimit
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestLimiterAllow(t *testing.T) {
+	now := time.Unix(0, 0)
+	limiter := New()
+	limiter.now = func() time.Time { return now }
+
+	limit := Limit{RPS: 1, Burst: 2}
+
+	for i := 0; i < 2; i++ {
+		if result := limiter.Allow("a", limit); !result.Allowed {
+			t.Fatalf("request %d rejected within burst", i+1)
+		}
+	}
+
+	result := limiter.Allow("a", limit)
+	if result.Allowed || result.RetryAfter != time.Second {
+		t.Fatalf("expected rejection with 1s retry, got %+v", result)
+	}
+
+	if result := limiter.Allow("b", limit); !result.Allowed {
+		t.Fatal("buckets are not separated by key")
+	}
+
+	now = now.Add(time.Second)
+	if result := limiter.Allow("a", limit); !result.Allowed || result.Remaining != 0 {
+		t.Fatalf("bucket did not refill, got %+v", result)
+	}
+}
+
+func TestRoutesMatch(t *testing.T) {
+	routes, err := ParseRoutes("POST /tokens/authentication=0.2:5, GET /car/:id/rentals=off, GET /images/*filepath=1:1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		method, path string
+		want         string
+	}{
+		{"POST", "/tokens/authentication", "POST /tokens/authentication"},
+		{"GET", "/tokens/authentication", ""},
+		{"GET", "/car/7/rentals", "GET /car/:id/rentals"},
+		{"GET", "/car/7", ""},
+		{"GET", "/images/cars/1/a.jpg", "GET /images/*filepath"},
+	}
+
+	for _, tt := range tests {
+		route, ok := routes.Match(tt.method, tt.path)
+		if got := route.Key(); ok && got != tt.want || !ok && tt.want != "" {
+			t.Errorf("%s %s: got %q, want %q", tt.method, tt.path, got, tt.want)
+		}
+	}
+
+	if _, err := ParseRoutes("POST /users=fast"); err == nil {
+		t.Error("expected an error for an invalid limit")
+	}
+}
+
+func TestClientIP(t *testing.T) {
+	trusted, err := ParseTrustedProxies("10.0.0.0/8, 192.168.1.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		forwarded  string
+		want       string
+	}{
+		{"direct", "203.0.113.5:1234", "", "203.0.113.5"},
+		{"untrusted proxy", "203.0.113.5:1234", "198.51.100.1", "203.0.113.5"},
+		{"trusted proxy", "10.0.0.2:1234", "198.51.100.1", "198.51.100.1"},
+		{"spoofed header", "10.0.0.2:1234", "1.2.3.4, 198.51.100.1, 192.168.1.1", "198.51.100.1"},
+		{"only proxies", "10.0.0.2:1234", "10.0.0.3", "10.0.0.3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/", nil)
+			r.RemoteAddr = tt.remoteAddr
+			if tt.forwarded != "" {
+				r.Header.Set("X-Forwarded-For", tt.forwarded)
+			}
+
+			if got := ClientIP(r, trusted); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
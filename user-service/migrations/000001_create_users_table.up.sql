@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS users (
+    id bigserial PRIMARY KEY,
+    created_at timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    name text NOT NULL,
+    surname text NOT NULL,
+    email text UNIQUE NOT NULL,
+    password_hash bytea NOT NULL,
+    activated bool NOT NULL DEFAULT false,
+    roles text NOT NULL DEFAULT 'DEFAULT',
+    owned_car integer NOT NULL DEFAULT 0,
+    rented_car integer NOT NULL DEFAULT 0
+);
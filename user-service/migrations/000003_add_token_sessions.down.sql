@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS tokens_expiry_idx;
+
+ALTER TABLE tokens DROP COLUMN IF EXISTS last_used_at;
+ALTER TABLE tokens DROP COLUMN IF EXISTS created_at;
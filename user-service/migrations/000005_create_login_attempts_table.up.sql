@@ -0,0 +1,14 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS failed_logins integer NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS locked_until timestamp(0) with time zone;
+
+CREATE TABLE IF NOT EXISTS login_attempts (
+    id bigserial PRIMARY KEY,
+    user_id bigint REFERENCES users ON DELETE CASCADE,
+    email text NOT NULL,
+    ip text NOT NULL,
+    succeeded bool NOT NULL,
+    created_at timestamp(0) with time zone NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS login_attempts_user_id_idx ON login_attempts (user_id, created_at);
+CREATE INDEX IF NOT EXISTS login_attempts_ip_idx ON login_attempts (ip, created_at);
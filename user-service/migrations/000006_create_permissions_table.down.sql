@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS permissions;
+
+ALTER TABLE users ALTER COLUMN roles DROP DEFAULT;
+ALTER TABLE users ALTER COLUMN roles TYPE text USING COALESCE(roles[array_upper(roles, 1)], 'DEFAULT');
+ALTER TABLE users ALTER COLUMN roles SET DEFAULT 'DEFAULT';
@@ -0,0 +1,23 @@
+ALTER TABLE users ALTER COLUMN roles DROP DEFAULT;
+ALTER TABLE users ALTER COLUMN roles TYPE text[] USING ARRAY[roles];
+ALTER TABLE users ALTER COLUMN roles SET DEFAULT '{DEFAULT}';
+
+CREATE TABLE IF NOT EXISTS permissions (
+    role text NOT NULL,
+    code text NOT NULL,
+    PRIMARY KEY (role, code)
+);
+
+INSERT INTO permissions (role, code)
+VALUES
+    ('DEFAULT', 'cars:write'),
+    ('MODERATOR', 'cars:write'),
+    ('MODERATOR', 'cars:moderate'),
+    ('MODERATOR', 'rentals:read'),
+    ('MODERATOR', 'users:read'),
+    ('ADMIN', 'cars:write'),
+    ('ADMIN', 'cars:moderate'),
+    ('ADMIN', 'rentals:read'),
+    ('ADMIN', 'users:read'),
+    ('ADMIN', 'users:admin')
+ON CONFLICT DO NOTHING;